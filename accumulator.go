@@ -0,0 +1,85 @@
+package fraction
+
+import "math/big"
+
+// ProductAccumulator multiplies fractions incrementally, the zero value is ready to use and holds the value 1.
+//
+// As long as the running product fits, it's kept as a Fraction. When a multiplication would overflow, it escalates
+// to a big.Rat internally, and it goes back to a Fraction as soon as the product fits again
+type ProductAccumulator struct {
+	v       Fraction
+	big     *big.Rat
+	started bool
+}
+
+// current returns the running product when it's stored as a Fraction
+func (p *ProductAccumulator) current() Fraction {
+	if !p.started {
+		return One()
+	}
+	return p.v
+}
+
+// Multiply multiplies the running product by f
+func (p *ProductAccumulator) Multiply(f Fraction) {
+	if p.big == nil {
+		v, err := Multiply(p.current(), f)
+		if err == nil {
+			p.v, p.started = v, true
+			return
+		}
+		p.big = p.current().rat()
+	}
+
+	p.big.Mul(p.big, f.rat())
+	if v, err := fromRat(p.big); err == nil {
+		p.v, p.started, p.big = v, true, nil
+	}
+}
+
+// Fraction returns the running product
+//
+// Can return ErrOutOfRange if the reduced product doesn't fit in a Fraction
+func (p *ProductAccumulator) Fraction() (Fraction, error) {
+	if p.big != nil {
+		return fromRat(p.big)
+	}
+	return p.current(), nil
+}
+
+// MinMaxTracker keeps track of the minimum and maximum of a stream of fractions without storing them,
+// the zero value is ready to use
+type MinMaxTracker struct {
+	min, max Fraction
+	seen     bool
+}
+
+// Observe feeds a new fraction into the tracker
+func (m *MinMaxTracker) Observe(f Fraction) {
+	if !m.seen {
+		m.min, m.max, m.seen = f, f, true
+		return
+	}
+	if Cmp(f, m.min) < 0 {
+		m.min = f
+	}
+	if Cmp(f, m.max) > 0 {
+		m.max = f
+	}
+}
+
+// Min returns the smallest fraction observed so far, the bool is false if nothing was observed yet
+func (m *MinMaxTracker) Min() (Fraction, bool) {
+	if !m.seen {
+		return zeroValue, false
+	}
+	return m.min, true
+}
+
+// Max returns the biggest fraction observed so far, the bool is false if nothing was observed yet
+func (m *MinMaxTracker) Max() (Fraction, bool) {
+	if !m.seen {
+		return zeroValue, false
+	}
+	return m.max, true
+}
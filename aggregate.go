@@ -0,0 +1,230 @@
+package fraction
+
+import (
+	"maps"
+	"slices"
+)
+
+// EvalPolynomial evaluates the polynomial with the given coefficients at x using Horner's method
+//
+// Coefficients go from the highest degree term to the constant term, so x^2 - 2 is []Fraction{1, 0, -2}.
+// An empty coefficient slice evaluates to Zero(). Can return ErrOutOfRange if any intermediate step overflows
+func EvalPolynomial(coeffs []Fraction, x Fraction) (Fraction, error) {
+	acc := zeroValue
+	for _, c := range coeffs {
+		var err error
+		if acc, err = Multiply(acc, x); err != nil {
+			return zeroValue, err
+		}
+		if acc, err = Add(acc, c); err != nil {
+			return zeroValue, err
+		}
+	}
+	return acc, nil
+}
+
+// Fold runs fn over the fractions from left to right, feeding each result back as the accumulator, starting
+// from init, and returns the final accumulator. Fold(fs, Zero(), Add) adds them all up. An empty slice returns init.
+//
+// The first error returned by fn stops the fold and is returned as it is
+func Fold(fs []Fraction, init Fraction, fn func(acc, x Fraction) (Fraction, error)) (Fraction, error) {
+	acc := init
+	for _, x := range fs {
+		var err error
+		if acc, err = fn(acc, x); err != nil {
+			return zeroValue, err
+		}
+	}
+	return acc, nil
+}
+
+// Differences returns the first differences of the fractions, result[i] = fs[i+1] - fs[i], so the result has one
+// element less than fs. Inputs with less than two elements return an empty slice.
+//
+// It returns ErrOutOfRange if any of the differences overflows
+func Differences(fs []Fraction) ([]Fraction, error) {
+	if len(fs) < 2 {
+		return []Fraction{}, nil
+	}
+
+	res := make([]Fraction, len(fs)-1)
+	for i := range res {
+		var err error
+		if res[i], err = Subtract(fs[i+1], fs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// NormalizeToSum scales every fraction by the same factor so that the result adds up exactly to target,
+// each element becomes f * target / sum(fs). [1, 1, 2] normalized to 1 returns [1/4, 1/4, 1/2].
+//
+// It returns ErrDivideByZero if the fractions add up to zero (which includes an empty slice) and can return
+// ErrOutOfRange if any of the steps overflow
+func NormalizeToSum(fs []Fraction, target Fraction) ([]Fraction, error) {
+	sum := zeroValue
+	for _, f := range fs {
+		var err error
+		if sum, err = Add(sum, f); err != nil {
+			return nil, err
+		}
+	}
+	if sum.isZero() {
+		return nil, ErrDivideByZero
+	}
+
+	scale, err := Divide(target, sum)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Fraction, len(fs))
+	for i, f := range fs {
+		if res[i], err = Multiply(f, scale); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// ParallelCombine returns the reciprocal of the sum of reciprocals, 1 / (1/a + 1/b + ...), which is how resistors
+// combine in parallel (or capacitors in series). ParallelCombine(2, 2) returns 1.
+//
+// It returns ErrInvalid if no fractions are given, ErrDivideByZero if any of them is zero or if the reciprocals
+// cancel each other out, and can return ErrOutOfRange if the sum overflows
+func ParallelCombine(fs ...Fraction) (Fraction, error) {
+	if len(fs) == 0 {
+		return zeroValue, ErrInvalid
+	}
+
+	sum := zeroValue
+	for _, f := range fs {
+		if f.isZero() {
+			return zeroValue, ErrDivideByZero
+		}
+		inv, err := Invert(f)
+		if err != nil {
+			return zeroValue, err
+		}
+		if sum, err = Add(sum, inv); err != nil {
+			return zeroValue, err
+		}
+	}
+
+	if sum.isZero() {
+		return zeroValue, ErrDivideByZero
+	}
+	return Invert(sum)
+}
+
+// SumValues adds up every value of the map
+//
+// The result doesn't depend on the map order, but an intermediate sum could overflow in one order and not in
+// another, so the values are always added in ascending key order to keep errors reproducible.
+// It returns ErrInvalid for an empty map and ErrOutOfRange if the sum overflows
+func SumValues(m map[string]Fraction) (Fraction, error) {
+	if len(m) == 0 {
+		return zeroValue, ErrInvalid
+	}
+
+	sum := zeroValue
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		var err error
+		if sum, err = Add(sum, m[k]); err != nil {
+			return zeroValue, err
+		}
+	}
+	return sum, nil
+}
+
+// MaxByValue returns the key holding the biggest value of the map along with that value. If several keys hold the
+// biggest value, the smallest key is returned.
+//
+// It returns ErrInvalid for an empty map
+func MaxByValue(m map[string]Fraction) (string, Fraction, error) {
+	if len(m) == 0 {
+		return "", zeroValue, ErrInvalid
+	}
+
+	var bestKey string
+	var best Fraction
+	for i, k := range slices.Sorted(maps.Keys(m)) {
+		if i == 0 || Cmp(m[k], best) > 0 {
+			bestKey, best = k, m[k]
+		}
+	}
+	return bestKey, best, nil
+}
+
+// Multiples returns the first n multiples of f, [f, 2f, ..., nf], built by adding f over and over, which is the
+// skip counting you'd draw on a number line. n == 0 returns an empty slice.
+//
+// It returns ErrInvalid if n is negative and ErrOutOfRange as soon as one of the multiples overflows
+func (f Fraction) Multiples(n int) ([]Fraction, error) {
+	if n < 0 {
+		return nil, ErrInvalid
+	}
+
+	res := make([]Fraction, 0, n)
+	acc := zeroValue
+	for range n {
+		var err error
+		if acc, err = Add(acc, f); err != nil {
+			return nil, err
+		}
+		res = append(res, acc)
+	}
+	return res, nil
+}
+
+// DivideEqually splits f into n identical shares of exactly f/n, so they always add back up to f. 7 split in 3 is
+// [7/3, 7/3, 7/3].
+//
+// It returns ErrInvalid if n isn't positive and ErrOutOfRange if f/n doesn't fit
+func (f Fraction) DivideEqually(n int) ([]Fraction, error) {
+	if n <= 0 {
+		return nil, ErrInvalid
+	}
+
+	share, err := Divide(f, NewI(n))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]Fraction, n)
+	for i := range res {
+		res[i] = share
+	}
+	return res, nil
+}
+
+// AllocateIntegers splits f into n whole shares that add up to f rounded to the nearest integer (halves away from
+// zero). Every share gets the floor of total/n and the leftover units go one each to the first shares, so 7 in 3
+// is [3, 2, 2], 15/2 rounds to 8 and gives [3, 3, 2], and -7 in 3 is [-2, -2, -3].
+//
+// It returns ErrInvalid if n isn't positive and ErrOutOfRange if the rounded total doesn't fit in an int64
+func (f Fraction) AllocateIntegers(n int) ([]int64, error) {
+	if n <= 0 {
+		return nil, ErrInvalid
+	}
+	total, ok := roundInt(f, RoundNearest)
+	if !ok {
+		return nil, ErrOutOfRange
+	}
+
+	// Floored division, so the leftover is always in [0, n)
+	q, r := total/int64(n), total%int64(n)
+	if r < 0 {
+		q--
+		r += int64(n)
+	}
+
+	res := make([]int64, n)
+	for i := range res {
+		res[i] = q
+		if int64(i) < r {
+			res[i]++
+		}
+	}
+	return res, nil
+}
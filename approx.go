@@ -0,0 +1,495 @@
+package fraction
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// ApproximationError returns the exact residual of an approximation, exact - approx
+//
+// A positive result means the approximation falls short of the exact value and a negative one means it overshoots.
+// Useful after FromFloat64Approx when the residual has to be kept as a fraction instead of a float.
+// Can return ErrOutOfRange if the subtraction overflows
+func ApproximationError(exact Fraction, approx Fraction) (Fraction, error) {
+	return Subtract(exact, approx)
+}
+
+// ApproximationQuality scores approx as an approximation of target with |approx - target| * q^2, where q is the
+// denominator of approx. Lower is better, and the score tells apart approximations that beat the 1/q^2 bound every
+// convergent meets (scores below 1) from the rest, so against pi 355/113 scores about 0.0034 while 22/7 scores about
+// 0.062. It's computed with big.Rat, so the intermediate steps never overflow.
+//
+// It returns ErrOutOfRange if the score doesn't fit
+func ApproximationQuality(approx, target Fraction) (Fraction, error) {
+	q := new(big.Int).SetUint64(approx.denominator)
+	score := new(big.Rat).Sub(approx.rat(), target.rat())
+	score.Abs(score)
+	score.Mul(score, new(big.Rat).SetInt(q.Mul(q, q)))
+	return fromRat(score)
+}
+
+// SimplestBetween returns the fraction with the smallest denominator that lies strictly between a and b
+//
+// It walks the Stern-Brocot tree through the continued fraction expansions of both bounds, so
+// SimplestBetween(1/3, 1/2) returns 2/5. If an integer fits in the interval, the one closest to zero is returned.
+// It returns ErrInvalid if a >= b and ErrOutOfRange if the result doesn't fit
+func SimplestBetween(a, b Fraction) (Fraction, error) {
+	if Cmp(a, b) >= 0 {
+		return zeroValue, ErrInvalid
+	}
+
+	// The interval crosses zero, nothing is simpler than zero itself
+	if a.negative && !b.negative && !b.isZero() {
+		return zeroValue, nil
+	}
+
+	// Both bounds are non-positive, solve it mirrored and negate back
+	if a.negative {
+		res, err := SimplestBetween(b.Negate(), a.Negate())
+		return res.Negate(), err
+	}
+
+	p, q, err := simplestBetween(a.numerator, a.denominator, b.numerator, b.denominator)
+	if err != nil {
+		return zeroValue, err
+	}
+	return Fraction{numerator: p, denominator: q}.normalize(), nil
+}
+
+// SimplestWithin returns the fraction with the smallest denominator whose distance from f is at most tol, the
+// nicest value that still meets a precision requirement. It's the counterpart of SnapToFarey, which caps the
+// denominator and minimizes the error, while this caps the error and minimizes the denominator, so 355/113
+// within 1/100 is 22/7 and within 1/5 is 3. Unlike SimplestBetween the bounds f-tol and f+tol can be the answer,
+// when there's a tie the one closest to zero wins.
+//
+// It returns ErrInvalid if tol isn't positive and ErrOutOfRange if the bounds or the result don't fit
+func (f Fraction) SimplestWithin(tol Fraction) (Fraction, error) {
+	if tol.negative || tol.isZero() {
+		return zeroValue, ErrInvalid
+	}
+
+	lo, err := Subtract(f, tol)
+	if err != nil {
+		return zeroValue, err
+	}
+	hi, err := Add(f, tol)
+	if err != nil {
+		return zeroValue, err
+	}
+
+	best, err := SimplestBetween(lo, hi)
+	if err != nil {
+		return zeroValue, err
+	}
+	for _, bound := range []Fraction{lo, hi} {
+		if bound.denominator < best.denominator ||
+			bound.denominator == best.denominator && bound.numerator < best.numerator {
+			best = bound
+		}
+	}
+	return best, nil
+}
+
+// simplestBetween finds the simplest p/q in the open interval (an/ad, bn/bd) where 0 <= an/ad < bn/bd.
+// A bd of 0 means the interval has no upper bound
+func simplestBetween(an, ad, bn, bd uint64) (p, q uint64, err error) {
+	fl := an / ad
+	if fl == math.MaxUint64 {
+		return 0, 0, ErrOutOfRange
+	}
+
+	// The smallest integer above a is the answer if it's also below b
+	next := fl + 1
+	if bd == 0 {
+		return next, 1, nil
+	}
+	if hi, lo := bits.Mul64(next, bd); cmp128(hi, lo, 0, bn) < 0 {
+		return next, 1, nil
+	}
+
+	// Both bounds share the integer part, take it out and search between the reciprocals of the remainders.
+	// b > a >= fl, so fl*bd <= bn can't overflow
+	ar := an % ad
+	br := bn - fl*bd
+
+	p2, q2, err := simplestBetween(bd, br, ad, ar)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// fl + 1/(p2/q2) = (fl*p2 + q2) / p2
+	hi, num := bits.Mul64(fl, p2)
+	num, carry := bits.Add64(num, q2, 0)
+	if hi != 0 || carry != 0 {
+		return 0, 0, ErrOutOfRange
+	}
+	return num, p2, nil
+}
+
+// SnapToFarey returns the closest fraction to f whose denominator is at most order, that is, the nearest term of
+// the Farey sequence of that order (extended outside of [0, 1] for values beyond it). 3/10 at order 5 returns 1/3.
+//
+// When two candidates are equally close, the one with the smaller denominator wins. It returns ErrInvalid if
+// order is 0
+func (f Fraction) SnapToFarey(order uint64) (Fraction, error) {
+	if order == 0 {
+		return zeroValue, ErrInvalid
+	}
+	return limitDenominator(f, order), nil
+}
+
+// maxFareyRankOrder bounds the order FareyRank accepts, its sieve needs one 4 byte counter per denominator, which
+// is 64MB at the bound
+const maxFareyRankOrder = 1 << 24
+
+// FareyRank returns the zero-based position of f in the Farey sequence of the given order, the sorted list of
+// reduced fractions in [0, 1] with denominators up to order. In the order 5 sequence 0, 1/5, 1/4, 1/3, 2/5, 1/2, ...
+// 1/2 has rank 5.
+//
+// The sequence isn't generated, the rank is the amount of reduced fractions in (0, f], counted per denominator q
+// as floor(f*q) minus the fractions that reduce to a smaller denominator dividing q. This is still linear in the
+// order, it takes O(order log order) time and O(order) memory, so orders are capped at 2^24.
+//
+// It returns ErrInvalid if order is 0, f isn't in [0, 1] or its denominator is bigger than order, and
+// ErrOutOfRange for orders above 2^24
+func FareyRank(f Fraction, order uint64) (int, error) {
+	if order == 0 || f.negative || f.numerator > f.denominator || f.denominator > order {
+		return 0, ErrInvalid
+	}
+	if order > maxFareyRankOrder {
+		return 0, ErrOutOfRange
+	}
+
+	// count[q] starts as floor(f*q), the fractions p/q in (0, f] reduced or not, and ends as only the reduced ones.
+	// It never goes above q, so it fits in an int32
+	n := int(order)
+	count := make([]int32, n+1)
+	for q := 1; q <= n; q++ {
+		floor, _, _ := scaledFloor(f, uint64(q))
+		count[q] = int32(floor)
+	}
+
+	rank := 0
+	for d := 1; d <= n; d++ {
+		rank += int(count[d])
+		for q := 2 * d; q <= n; q += d {
+			count[q] -= count[d]
+		}
+	}
+	return rank, nil
+}
+
+// limitDenominator returns the closest fraction to f with a denominator of at most maxDen (which must be positive).
+// It walks the convergents of f until the denominator bound is hit, then picks between the last convergent and
+// the best semiconvergent, with ties going to the smaller denominator
+func limitDenominator(f Fraction, maxDen uint64) Fraction {
+	if f.denominator <= maxDen {
+		return f
+	}
+
+	conv, semi := fareyNeighbors(f, maxDen)
+	return closest(f, conv, semi)
+}
+
+// BracketingConvergents returns the best approximations of f from below and from above whose denominators are at
+// most maxDen, that is, its two neighbours in the Farey sequence of that order. They're the last convergent of f
+// that fits and the largest semiconvergent after it, which always sit on opposite sides of f, so for 1/3 at a
+// maxDen of 2 they're 0 and 1/2. If f's own denominator fits, f is returned as both bounds.
+// It returns ErrInvalid if maxDen is 0, since no fraction has a denominator that small and any pair of bounds
+// returned for it would be wrong, the same as the other bounds in this file
+func BracketingConvergents(f Fraction, maxDen uint64) (lower Fraction, upper Fraction, err error) {
+	if maxDen == 0 {
+		return zeroValue, zeroValue, ErrInvalid
+	}
+	if f.denominator <= maxDen {
+		return f, f, nil
+	}
+
+	lower, upper = fareyNeighbors(f, maxDen)
+	if lower.Greater(upper) {
+		lower, upper = upper, lower
+	}
+	return lower, upper, nil
+}
+
+// FloorToDenominator returns the largest fraction with a denominator of at most maxDen that doesn't exceed f,
+// unlike SnapToFarey it never rounds up, so 0.34 with a maxDen of 3 returns 1/3 and 0.32 returns 0.
+// f is returned unchanged if its denominator already fits. It returns ErrInvalid if maxDen is 0, since there's no
+// grid to snap to then and returning anything would break the never rounds up guarantee
+func (f Fraction) FloorToDenominator(maxDen uint64) (Fraction, error) {
+	lower, _, err := BracketingConvergents(f, maxDen)
+	return lower, err
+}
+
+// CeilToDenominator returns the smallest fraction with a denominator of at most maxDen that isn't below f,
+// the counterpart of FloorToDenominator, 0.34 with a maxDen of 3 returns 1/2.
+// f is returned unchanged if its denominator already fits. It returns ErrInvalid if maxDen is 0, for the same
+// reason as FloorToDenominator
+func (f Fraction) CeilToDenominator(maxDen uint64) (Fraction, error) {
+	_, upper, err := BracketingConvergents(f, maxDen)
+	return upper, err
+}
+
+// NearestHarmonic returns the closest fraction to f of the form n/1 or 1/n with n between 1 and maxHarmonic, which
+// is how a frequency ratio snaps to a harmonic or a subharmonic. 7/3 returns 2 and 3/10 returns 1/3. When two
+// harmonics are equally close, the one with the smaller n wins, so 5/2 returns 2.
+//
+// It returns ErrInvalid if maxHarmonic is 0 or f isn't positive, since harmonics are all positive
+func (f Fraction) NearestHarmonic(maxHarmonic uint64) (Fraction, error) {
+	if maxHarmonic == 0 || f.negative || f.isZero() {
+		return zeroValue, ErrInvalid
+	}
+
+	// Above 1 the candidates are the integers around f, below 1 the unit fractions around it
+	n, d := f.numerator, f.denominator
+	below := n < d
+	if below {
+		n, d = d, n
+	}
+	lo := min(n/d, maxHarmonic)
+	hi := lo
+	if lo < maxHarmonic {
+		hi++
+	}
+
+	if below {
+		return closest(f, Fraction{numerator: 1, denominator: lo}, Fraction{numerator: 1, denominator: hi}), nil
+	}
+	return closest(f, NewI(lo), NewI(hi)), nil
+}
+
+// maxGeometricPowerBits bounds the size of the exact powers NearestGeometric goes through
+const maxGeometricPowerBits = 1 << 20
+
+// NearestGeometric snaps f to the closest power base^k with k between -maxExp and maxExp, returning it along with
+// k, the multiplicative counterpart of snapping to a grid. Closeness is measured in ratios, not differences, since
+// that's what a geometric grid is about: with a base of 2, 3 is an octave away from 4 and 3/2 from 2, so 3 snaps
+// to 4 and 3/2 snaps to 2, and an exact tie like 2 between powers of 4 goes to the lower power.
+//
+// The powers are computed exactly and returned as they are when their denominator is at most maxDen, otherwise
+// (or when they don't fit) they're approximated with FromFloat64Approx, with the usual float64 precision caveats.
+//
+// It returns ErrInvalid if f isn't positive, base isn't above 1, maxExp is negative or maxDen is 0, and
+// ErrOutOfRange if the power is too big to be worked out or represented
+func (f Fraction) NearestGeometric(base Fraction, maxExp int, maxDen uint64) (Fraction, int, error) {
+	if f.isZero() || f.negative || base.negative || base.numerator <= base.denominator || maxExp < 0 || maxDen == 0 {
+		return zeroValue, 0, ErrInvalid
+	}
+
+	// Estimate k with logarithms, then settle it exactly
+	lnF := math.Log(float64(f.numerator)) - math.Log(float64(f.denominator))
+	lnBase := math.Log1p(float64(base.numerator-base.denominator) / float64(base.denominator))
+	est := math.Round(lnF / lnBase)
+	k := int(max(min(est, float64(maxExp)), float64(-maxExp)))
+
+	powerBits := bits.Len64(base.numerator) + bits.Len64(base.denominator)
+	if abs(k)+1 > maxGeometricPowerBits/powerBits {
+		return zeroValue, 0, ErrOutOfRange
+	}
+
+	// f is closer to p = base^k than to p*base when f^2 <= p^2*base, and closer than to p/base when f^2 > p^2/base
+	f2 := f.rat()
+	f2.Mul(f2, f2)
+	b := base.rat()
+	for {
+		p := ratPow(b, k)
+		p2 := new(big.Rat).Mul(p, p)
+		if k < maxExp && f2.Cmp(new(big.Rat).Mul(p2, b)) > 0 {
+			k++
+			continue
+		}
+		if k > -maxExp && f2.Cmp(new(big.Rat).Quo(p2, b)) <= 0 {
+			k--
+			continue
+		}
+
+		if res, err := fromRat(p); err == nil && res.denominator <= maxDen {
+			return res, k, nil
+		}
+		pf, _ := p.Float64()
+		if pf >= math.MaxUint64 {
+			return zeroValue, 0, ErrOutOfRange
+		}
+		res, err := FromFloat64Approx(pf, maxDen)
+		return res, k, err
+	}
+}
+
+// ratPow returns r^k for a positive r, k can be negative
+func ratPow(r *big.Rat, k int) *big.Rat {
+	e := big.NewInt(int64(abs(k)))
+	num := new(big.Int).Exp(r.Num(), e, nil)
+	den := new(big.Int).Exp(r.Denom(), e, nil)
+	if k < 0 {
+		num, den = den, num
+	}
+	return new(big.Rat).SetFrac(num, den)
+}
+
+// fareyNeighbors returns the last convergent of f with a denominator of at most maxDen and the largest
+// semiconvergent that follows it, f.denominator must be above maxDen (which must be positive)
+func fareyNeighbors(f Fraction, maxDen uint64) (conv Fraction, semi Fraction) {
+	var p0, q0, p1, q1 uint64 = 0, 1, 1, 0
+	n, d := f.numerator, f.denominator
+	for d != 0 {
+		a := n / d
+		// Convergent denominators never exceed f's, so this can't overflow
+		q2 := q0 + a*q1
+		if q2 > maxDen {
+			break
+		}
+		p0, q0, p1, q1 = p1, q1, p0+a*p1, q2
+		n, d = d, n-a*d
+	}
+
+	k := (maxDen - q0) / q1
+	semi = Fraction{numerator: p0 + k*p1, denominator: q0 + k*q1, negative: f.negative}.normalize()
+	conv = Fraction{numerator: p1, denominator: q1, negative: f.negative}.normalize()
+	return conv, semi
+}
+
+// closest returns whichever of a and b is closer to target, ties go to the one with the smaller denominator
+func closest(target, a, b Fraction) Fraction {
+	t := target.rat()
+	da := new(big.Rat).Sub(a.rat(), t)
+	db := new(big.Rat).Sub(b.rat(), t)
+
+	switch da.Abs(da).Cmp(db.Abs(db)) {
+	case -1:
+		return a
+	case 1:
+		return b
+	}
+	if b.denominator < a.denominator {
+		return b
+	}
+	return a
+}
+
+// FromFloatRatio approximates num/den as a fraction with a denominator of at most maxDen
+//
+// The quotient is computed once and goes through FromFloat64Approx, which avoids the huge dyadic fractions that
+// converting both floats separately and dividing would produce. It returns ErrInvalid if either float is NaN or
+// infinite (or if maxDen is 0), ErrDivideByZero if den is 0 and ErrOutOfRange if the quotient is too big
+func FromFloatRatio(num, den float64, maxDen uint64) (Fraction, error) {
+	if math.IsNaN(num) || math.IsNaN(den) || math.IsInf(num, 0) || math.IsInf(den, 0) {
+		return zeroValue, ErrInvalid
+	}
+	if den == 0 {
+		return zeroValue, ErrDivideByZero
+	}
+
+	q := num / den
+	if math.Abs(q) >= math.MaxUint64 {
+		return zeroValue, ErrOutOfRange
+	}
+	return FromFloat64Approx(q, maxDen)
+}
+
+// GeometricMean approximates the geometric mean of strictly positive fractions with a denominator of at most maxDen
+//
+// The geometric mean is usually irrational, so the result is approximate: the product is computed exactly, its
+// n-th root is taken in floating point and then approximated with FromFloat64Approx. The result is as close as a
+// fraction with that denominator bound can get to the float root, which itself carries float64 precision (about 15
+// significant digits). GeometricMean([1/2, 2], 10) returns 1.
+//
+// It returns ErrInvalid for an empty slice, for any element that isn't positive or if maxDen is 0, and
+// ErrOutOfRange if the mean is too big to be represented
+func GeometricMean(fs []Fraction, maxDen uint64) (Fraction, error) {
+	if len(fs) == 0 || maxDen == 0 {
+		return zeroValue, ErrInvalid
+	}
+
+	product := big.NewRat(1, 1)
+	for _, f := range fs {
+		if f.isZero() || f.negative {
+			return zeroValue, ErrInvalid
+		}
+		product.Mul(product, f.rat())
+	}
+
+	// Going through logarithms keeps products beyond the float64 range working
+	logMean := (bigLog(product.Num()) - bigLog(product.Denom())) / float64(len(fs))
+	mean := math.Exp(logMean)
+	if mean >= math.MaxUint64 {
+		return zeroValue, ErrOutOfRange
+	}
+	return FromFloat64Approx(mean, maxDen)
+}
+
+// Log2Approx approximates log2(f) with a denominator of at most maxDen.
+//
+// When f is a power of two (including negative powers like 1/8) the result is exact, so Log2Approx(8) is 3 and
+// Log2Approx(1/4) is -2. Otherwise the logarithm is taken in floating point, from the logarithms of the numerator
+// and denominator to keep as much precision as possible, and approximated with FromFloat64Approx, so the result
+// is as close as the denominator bound allows to a value carrying float64 precision (about 15 significant digits).
+//
+// It returns ErrInvalid if f isn't positive or if maxDen is 0
+func Log2Approx(f Fraction, maxDen uint64) (Fraction, error) {
+	if f.isZero() || f.negative || maxDen == 0 {
+		return zeroValue, ErrInvalid
+	}
+
+	// Fractions are reduced, so a power of two has a 1 on one side and a single bit on the other
+	if bits.OnesCount64(f.numerator) == 1 && bits.OnesCount64(f.denominator) == 1 {
+		return NewI(bits.TrailingZeros64(f.numerator) - bits.TrailingZeros64(f.denominator)), nil
+	}
+	return FromFloat64Approx(math.Log2(float64(f.numerator))-math.Log2(float64(f.denominator)), maxDen)
+}
+
+// LnApprox approximates the natural logarithm of f with a denominator of at most maxDen.
+//
+// The only rational value of ln is ln(1) = 0, which is returned exactly. Everything else is taken in floating point
+// and approximated with FromFloat64Approx, with the same float64 precision caveats as Log2Approx.
+//
+// It returns ErrInvalid if f isn't positive or if maxDen is 0
+func LnApprox(f Fraction, maxDen uint64) (Fraction, error) {
+	if f.isZero() || f.negative || maxDen == 0 {
+		return zeroValue, ErrInvalid
+	}
+	return FromFloat64Approx(math.Log(float64(f.numerator))-math.Log(float64(f.denominator)), maxDen)
+}
+
+// bigLog returns the natural logarithm of a positive big.Int, even if it's too big to fit in a float64
+func bigLog(x *big.Int) float64 {
+	shift := max(x.BitLen()-64, 0)
+	top, _ := new(big.Float).SetInt(new(big.Int).Rsh(x, uint(shift))).Float64()
+	return math.Log(top) + float64(shift)*math.Ln2
+}
+
+// maxApproxPrime bounds the maxPrime ApproxPrimeDenominator accepts, its sieve needs one byte per number
+const maxApproxPrime = 1 << 24
+
+// ApproxPrimeDenominator returns the fraction closest to f among those of the form k/p, with p a prime <= maxPrime
+//
+// Every prime up to maxPrime is tried (they're found with a sieve, which is why maxPrime is capped at 2^24)
+// taking k = round(f*p). Ties go to the smaller denominator. Note the result is reduced, so integers come back
+// as k/1. It returns ErrInvalid if maxPrime < 2 and ErrOutOfRange if maxPrime is above 2^24 or k doesn't fit in
+// an int64
+func (f Fraction) ApproxPrimeDenominator(maxPrime uint64) (Fraction, error) {
+	if maxPrime < 2 {
+		return zeroValue, ErrInvalid
+	}
+	if maxPrime > maxApproxPrime {
+		return zeroValue, ErrOutOfRange
+	}
+
+	var best Fraction
+	for i, p := range primesUpTo(maxPrime) {
+		k, ok := scaledRound(f, p)
+		if !ok {
+			return zeroValue, ErrOutOfRange
+		}
+		cand := Fraction{numerator: uint64(abs(k)), denominator: p, negative: k < 0}.normalize()
+		if i == 0 {
+			best = cand
+			continue
+		}
+		best = closest(f, best, cand)
+		if best.Equal(f) {
+			break
+		}
+	}
+	return best, nil
+}
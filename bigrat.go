@@ -0,0 +1,30 @@
+package fraction
+
+import "math/big"
+
+// rat converts the fraction into a big.Rat
+func (f Fraction) rat() *big.Rat {
+	r := new(big.Rat).SetFrac(
+		new(big.Int).SetUint64(f.numerator),
+		new(big.Int).SetUint64(f.denominator),
+	)
+	if f.negative {
+		r.Neg(r)
+	}
+	return r
+}
+
+// fromRat converts a big.Rat back into a fraction, big.Rat is always reduced so this only has to check the range.
+// Returns ErrOutOfRange if either the numerator or the denominator don't fit in an uint64
+func fromRat(r *big.Rat) (Fraction, error) {
+	num := new(big.Int).Abs(r.Num())
+	den := r.Denom()
+	if !num.IsUint64() || !den.IsUint64() {
+		return zeroValue, ErrOutOfRange
+	}
+	return Fraction{
+		numerator:   num.Uint64(),
+		denominator: den.Uint64(),
+		negative:    r.Sign() < 0,
+	}.normalize(), nil
+}
@@ -0,0 +1,103 @@
+package fraction
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// cmpFastEpsilon is the relative gap above which two Float64() values are trusted to be ordered like the
+// fractions they come from. Float64() rounds the numerator, the denominator and the quotient, so its relative
+// error is at most about 3*2^-53, this leaves plenty of margin
+const cmpFastEpsilon = 0x1p-48
+
+// ComparatorAgainst returns a function that compares f against other fractions, returning the same as Cmp(f, g)
+//
+// The fraction fields are captured once and the comparison goes straight to a 128-bit cross multiplication
+// without computing a gcd, which makes it noticeably faster when comparing one value against many, like in
+// binary searches or bucketing loops
+func (f Fraction) ComparatorAgainst() func(Fraction) int {
+	num, den, neg := f.numerator, f.denominator, f.negative
+	zero := num == 0
+
+	return func(g Fraction) int {
+		if zero && g.numerator == 0 {
+			return 0
+		}
+
+		if neg != g.negative {
+			if neg {
+				return -1
+			}
+			return 1
+		}
+
+		// a/b ? c/d -> a*d ? c*b, both products fit in 128 bits
+		ahi, alo := bits.Mul64(num, g.denominator)
+		bhi, blo := bits.Mul64(g.numerator, den)
+
+		c := cmp128(ahi, alo, bhi, blo)
+		if neg {
+			return -c
+		}
+		return c
+	}
+}
+
+// CmpRaw compares f against numerator/denominator (negated if negative is true) like Cmp would, without building a
+// Fraction for it. The raw value doesn't have to be reduced, and a zero numerator is zero no matter the sign flag.
+// The comparison is a 128-bit cross multiplication, so there's no gcd and nothing can overflow.
+//
+// It returns ErrZeroDenominator if denominator is 0
+func (f Fraction) CmpRaw(numerator uint64, denominator uint64, negative bool) (int, error) {
+	if denominator == 0 {
+		return 0, ErrZeroDenominator
+	}
+	negative = negative && numerator != 0
+
+	if f.negative != negative {
+		if f.negative {
+			return -1, nil
+		}
+		return 1, nil
+	}
+
+	ahi, alo := bits.Mul64(f.numerator, denominator)
+	bhi, blo := bits.Mul64(numerator, f.denominator)
+	c := cmp128(ahi, alo, bhi, blo)
+	if negative {
+		return -c, nil
+	}
+	return c, nil
+}
+
+// CmpFast returns the same as Cmp(a, b) and so gives the exact same total order, but it first compares the
+// Float64() values and only falls back to the exact Cmp when they're too close for the floats to be trusted.
+// On clearly separated values this skips the 128-bit cross multiplication, so it's worth it for sorting data
+// where ties and near ties are rare
+func CmpFast(a, b Fraction) int {
+	fa, fb := a.Float64(), b.Float64()
+	if math.Abs(fa-fb) > cmpFastEpsilon*max(math.Abs(fa), math.Abs(fb)) {
+		if fa < fb {
+			return -1
+		}
+		return 1
+	}
+	return Cmp(a, b)
+}
+
+// FloatEqualButNotExact reports whether a and b have the same Float64() value while being different fractions,
+// the kind of pair a float comparison would wrongly call equal and Equal or Cmp tell apart. Values with large
+// denominators that are closer than the float precision, like 1 - 1/(2^63-1) and 1 - 1/(2^63-2), are the usual
+// culprits
+func FloatEqualButNotExact(a, b Fraction) bool {
+	return a.Float64() == b.Float64() && !a.Equal(b)
+}
+
+// CompareSlices compares two slices of fractions lexicographically, returning -1, 0 or +1 like Cmp
+//
+// Elements are compared in order with Cmp and the first difference decides, if one slice is a prefix of the
+// other, the shorter one goes first. This makes it usable with slices.SortFunc to sort rows of fractions
+func CompareSlices(a, b []Fraction) int {
+	return slices.CompareFunc(a, b, Cmp)
+}
@@ -0,0 +1,126 @@
+package fraction
+
+import (
+	"math"
+	"math/big"
+)
+
+// ConstantApproximation is a well known rational approximation of a mathematical constant
+type ConstantApproximation struct {
+	Name  string
+	Value Fraction
+}
+
+// ConstantApproximations is the table MatchesConstant looks into, it can be extended with your own entries
+var ConstantApproximations = []ConstantApproximation{
+	{"pi", MustNew(22, 7)},
+	{"pi", MustNew(333, 106)},
+	{"pi", MustNew(355, 113)},
+	{"e", MustNew(19, 7)},
+	{"e", MustNew(87, 32)},
+	{"e", MustNew(106, 39)},
+	{"e", MustNew(193, 71)},
+	{"phi", MustNew(13, 8)},
+	{"phi", MustNew(21, 13)},
+	{"phi", MustNew(34, 21)},
+	{"phi", MustNew(55, 34)},
+	{"phi", MustNew(89, 55)},
+}
+
+// MatchesConstant looks for an entry of ConstantApproximations within tol of f and returns the constant's name,
+// so 22/7 with a tolerance of 0 returns "pi". If several entries are close enough, the closest one wins.
+// ok is false if no entry is within tol
+func (f Fraction) MatchesConstant(tol Fraction) (name string, ok bool) {
+	var best Fraction
+	for _, c := range ConstantApproximations {
+		diff, err := Subtract(f, c.Value)
+		if err != nil {
+			continue
+		}
+		diff = diff.Abs()
+		if diff.Greater(tol) || ok && !diff.Less(best) {
+			continue
+		}
+		name, best, ok = c.Name, diff, true
+	}
+	return name, ok
+}
+
+// The constants to 100 decimal places. Rational approximations with denominators up to 2^64 only depend on the
+// first 40 or so, so these give the same results as the exact values
+const (
+	piDigits  = "3.1415926535897932384626433832795028841971693993751058209749445923078164062862089986280348253421170679"
+	eDigits   = "2.7182818284590452353602874713526624977572470936999595749669676277240766303535475945713821785251664274"
+	phiDigits = "1.6180339887498948482045868343656381177203091798057628621354486227052604628189024497072072041893911374"
+)
+
+// Pi returns the closest fraction to pi with a denominator of at most maxDen, Pi(7) is 22/7, Pi(100) is 311/99 and
+// Pi(113) is 355/113. The numerator has to fit in an uint64 as well, which caps the denominator at about 5.8e18.
+// A maxDen of 0 is treated as 1
+func Pi(maxDen uint64) Fraction {
+	return constantApprox(piDigits, maxDen)
+}
+
+// E returns the closest fraction to Euler's number with a denominator of at most maxDen, E(7) is 19/7. The
+// numerator has to fit in an uint64 as well, which caps the denominator at about 6.8e18.
+// A maxDen of 0 is treated as 1
+func E(maxDen uint64) Fraction {
+	return constantApprox(eDigits, maxDen)
+}
+
+// Phi returns the closest fraction to the golden ratio with a denominator of at most maxDen, Phi(55) is 89/55. The
+// numerator has to fit in an uint64 as well, which caps the denominator at about 1.1e19.
+// A maxDen of 0 is treated as 1
+func Phi(maxDen uint64) Fraction {
+	return constantApprox(phiDigits, maxDen)
+}
+
+// constantApprox returns the best approximation of the constant written in digits with a denominator of at most
+// maxDen and a numerator that fits in an uint64. It walks the continued fraction of the constant with big.Int
+// and then picks between the last convergent that fits and the largest semiconvergent after it
+func constantApprox(digits string, maxDen uint64) Fraction {
+	x, _ := new(big.Rat).SetString(digits)
+	maxQ := new(big.Int).SetUint64(max(maxDen, 1))
+	maxP := new(big.Int).SetUint64(math.MaxUint64)
+
+	p0, q0, p1, q1 := big.NewInt(0), big.NewInt(1), big.NewInt(1), big.NewInt(0)
+	n, d := new(big.Int).Set(x.Num()), new(big.Int).Set(x.Denom())
+	a, r := new(big.Int), new(big.Int)
+	for d.Sign() != 0 {
+		a.QuoRem(n, d, r)
+		p2 := new(big.Int).Add(p0, new(big.Int).Mul(a, p1))
+		q2 := new(big.Int).Add(q0, new(big.Int).Mul(a, q1))
+		if q2.Cmp(maxQ) > 0 || p2.Cmp(maxP) > 0 {
+			break
+		}
+		p0, q0, p1, q1 = p1, q1, p2, q2
+		n, d, r = d, r, n
+	}
+
+	// The largest k for which (p0 + k*p1) / (q0 + k*q1) still fits both bounds
+	best := new(big.Rat).SetFrac(p1, q1)
+	kq := new(big.Int).Quo(new(big.Int).Sub(maxQ, q0), q1)
+	kp := new(big.Int).Quo(new(big.Int).Sub(maxP, p0), p1)
+	if k := bigMin(kq, kp); k.Sign() > 0 {
+		semi := new(big.Rat).SetFrac(
+			new(big.Int).Add(p0, new(big.Int).Mul(k, p1)),
+			new(big.Int).Add(q0, new(big.Int).Mul(k, q1)),
+		)
+		dConv := new(big.Rat).Sub(best, x)
+		dSemi := new(big.Rat).Sub(semi, x)
+		if dSemi.Abs(dSemi).Cmp(dConv.Abs(dConv)) < 0 {
+			best = semi
+		}
+	}
+
+	f, _ := fromRat(best)
+	return f
+}
+
+// bigMin returns the smaller of a and b
+func bigMin(a, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,164 @@
+package fraction
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ContinuedFraction returns the coefficients of the simple continued fraction of the absolute value of f
+//
+// 415/93 returns [4, 2, 6, 7], integers return a single coefficient and zero returns [0]. The sign is not part
+// of the coefficients, use IsNegative() to get it
+func (f Fraction) ContinuedFraction() []uint64 {
+	n, d := f.numerator, f.denominator
+
+	var coeffs []uint64
+	for d != 0 {
+		coeffs = append(coeffs, n/d)
+		n, d = d, n%d
+	}
+	return coeffs
+}
+
+// ContinuedFractionLength returns how many coefficients ContinuedFraction would return, without building the
+// slice. It's a cheap measure of how complicated a fraction is: integers have a length of 1 and 355/113 ([3; 7, 16])
+// has a length of 3
+func (f Fraction) ContinuedFractionLength() int {
+	n, d := f.numerator, f.denominator
+
+	length := 0
+	for d != 0 {
+		n, d = d, n%d
+		length++
+	}
+	return length
+}
+
+// ContinuedFractionString formats the continued fraction of f in the standard bracket notation
+//
+// 415/93 returns "[4; 2, 6, 7]", integers like 5 return "[5]" and negative values carry the sign on the
+// whole value, so -415/93 returns "-[4; 2, 6, 7]"
+func (f Fraction) ContinuedFractionString() string {
+	var str strings.Builder
+
+	if f.negative && f.numerator != 0 {
+		str.WriteRune('-')
+	}
+	str.WriteRune('[')
+	for i, c := range f.ContinuedFraction() {
+		switch i {
+		case 0:
+		case 1:
+			str.WriteString("; ")
+		default:
+			str.WriteString(", ")
+		}
+		str.WriteString(strconv.FormatUint(c, 10))
+	}
+	str.WriteRune(']')
+
+	return str.String()
+}
+
+// EvalGeneralizedContinuedFraction evaluates b0 + a1/(b1 + a2/(b2 + ... + an/bn)) from the tail up
+//
+// b holds b0 through bn and a holds a1 through an, so a must have exactly one element less than b, otherwise
+// ErrInvalid is returned (also for an empty b). It returns ErrDivideByZero if any of the partial denominators
+// becomes zero and ErrOutOfRange if any step overflows
+func EvalGeneralizedContinuedFraction(a []Fraction, b []Fraction) (Fraction, error) {
+	if len(b) == 0 || len(a) != len(b)-1 {
+		return zeroValue, ErrInvalid
+	}
+
+	v := b[len(b)-1]
+	for i := len(a) - 1; i >= 0; i-- {
+		if v.isZero() {
+			return zeroValue, ErrDivideByZero
+		}
+		var err error
+		if v, err = Start(a[i]).Div(v).Sum(b[i]).Result(); err != nil {
+			return zeroValue, err
+		}
+	}
+	return v, nil
+}
+
+// SimplerStep returns the convergent of f that drops the last term of its continued fraction, that is, the
+// next less accurate approximation with a smaller denominator. Calling it repeatedly walks 355/113 ([3; 7, 16])
+// through 22/7 and then 3. The sign of f is kept.
+// The bool is false when there's nothing simpler because f is already an integer
+func (f Fraction) SimplerStep() (Fraction, bool) {
+	coeffs := f.ContinuedFraction()
+	if len(coeffs) < 2 {
+		return f, false
+	}
+
+	// Convergents never exceed f's own numerator and denominator, so none of this can overflow
+	var p, pPrev, q, qPrev uint64 = 1, 0, 0, 1
+	for _, a := range coeffs[:len(coeffs)-1] {
+		p, pPrev = a*p+pPrev, p
+		q, qPrev = a*q+qPrev, q
+	}
+	return Fraction{numerator: p, denominator: q, negative: f.negative}.normalize(), true
+}
+
+// SemiConvergents returns every best rational approximation of f with a denominator of at most maxDen, from the
+// least to the most accurate. A best approximation is a fraction closer to f than any other fraction with a
+// smaller or equal denominator. They're all either convergents or semiconvergents (p[k-1] + j*p[k]) /
+// (q[k-1] + j*q[k]) with 0 < j <= a[k+1], and only the ones that beat the previous approximation are kept, so the
+// list for pi up to 113 is 3, 13/4, 16/5, 19/6, 22/7, 179/57, 201/64, ..., 333/106, 355/113.
+// The last element is f itself when its denominator fits, the sign of f is kept and a maxDen of 0 is treated as 1.
+//
+// A big partial quotient a[k+1] brings about a[k+1]/2 semiconvergents with it, so 1/10^12 alone has 5*10^11 best
+// approximations. It returns ErrOutOfRange if there are more than 2^20 candidates to check
+func (f Fraction) SemiConvergents(maxDen uint64) ([]Fraction, error) {
+	maxDen = max(maxDen, 1)
+	target := f.Abs().rat()
+
+	var res []Fraction
+	var bestDist *big.Rat
+	add := func(p, q uint64) {
+		c := Fraction{numerator: p, denominator: q}.normalize()
+		dist := new(big.Rat).Sub(target, c.rat())
+		dist.Abs(dist)
+		if bestDist != nil && dist.Cmp(bestDist) >= 0 {
+			return
+		}
+		// A closer candidate with the same denominator replaces the previous one
+		if len(res) > 0 && res[len(res)-1].denominator == q {
+			res = res[:len(res)-1]
+		}
+		res, bestDist = append(res, c), dist
+	}
+
+	// Every candidate is bounded by the last convergent, f itself, so nothing here can overflow
+	var p0, q0, p1, q1 uint64 = 0, 1, 1, 0
+	for k, a := range f.ContinuedFraction() {
+		// Semiconvergents with j below a/2 are never best approximations
+		j := max(a/2, 1)
+		if k == 0 {
+			j = a
+		} else if last := min(a, (maxDen-q0)/q1); last >= j && last-j >= uint64(maxFractionsCount-len(res)) {
+			return nil, ErrOutOfRange
+		}
+		for ; j <= a; j++ {
+			q := q0 + j*q1
+			if q > maxDen {
+				break
+			}
+			add(p0+j*p1, q)
+		}
+		p0, q0, p1, q1 = p1, q1, p0+a*p1, q0+a*q1
+		if q1 > maxDen {
+			break
+		}
+	}
+
+	if f.negative {
+		for i := range res {
+			res[i] = res[i].Negate()
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,183 @@
+package fraction
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"time"
+)
+
+// FromPercentInt creates a fraction from an integer percentage, 37 returns 37/100 and 50 returns 1/2
+func FromPercentInt(p int64) Fraction {
+	return MustNew(p, 100)
+}
+
+// FromBasisPoints creates a fraction from an amount of basis points (hundredths of a percent), so 25 returns 1/400
+func FromBasisPoints(bp int64) Fraction {
+	return MustNew(bp, 10000)
+}
+
+// FromFloat64s runs FromFloat64Approx with the same maxDen over every float in fs, so a single NaN doesn't abort
+// the whole batch. Both returned slices have the same length as fs, errs[i] holds the error for fs[i] (nil when it
+// converted fine) and fractions[i] is zero wherever errs[i] isn't nil
+func FromFloat64s(fs []float64, maxDen uint64) ([]Fraction, []error) {
+	fractions := make([]Fraction, len(fs))
+	errs := make([]error, len(fs))
+	for i, x := range fs {
+		fractions[i], errs[i] = FromFloat64Approx(x, maxDen)
+	}
+	return fractions, errs
+}
+
+// NearestFloat64Fraction shows exactly what a float64 round trip does to f. It returns the dyadic fraction that
+// f.Float64() actually holds and the error f minus that value, both exact, so 1/3 returns
+// 6004799503160661/18014398509481984 and 1/54043195528445952. Values that are exact floats like 3/8 return
+// themselves and a zero error.
+//
+// It returns ErrOutOfRange if the dyadic value or the error don't fit in a Fraction, which happens for values
+// whose float needs a denominator above 2^64
+func (f Fraction) NearestFloat64Fraction() (Fraction, Fraction, error) {
+	r := f.rat()
+	dyadic := new(big.Rat).SetFloat64(f.Float64())
+
+	near, err := fromRat(dyadic)
+	if err != nil {
+		return zeroValue, zeroValue, err
+	}
+	gap, err := fromRat(r.Sub(r, dyadic))
+	if err != nil {
+		return zeroValue, zeroValue, err
+	}
+	return near, gap, nil
+}
+
+// FixedPointError returns how far f is from its closest value in fixed point with the given number of fractional
+// bits (the n of a Qm.n format), that is |f - round(f * 2^n) / 2^n| with halves rounded away from zero. 1/3 with
+// 4 fractional bits is stored as 5/16, so the error is 1/48. The integer bits aren't considered, only the
+// precision lost after the point.
+//
+// It returns ErrOutOfRange if fractionalBits is above 63 or f * 2^n doesn't fit in an uint64
+func (f Fraction) FixedPointError(fractionalBits uint) (Fraction, error) {
+	if fractionalBits > 63 {
+		return zeroValue, ErrOutOfRange
+	}
+
+	scale := uint64(1) << fractionalBits
+	q, ok := roundedMagnitude(f, scale)
+	if !ok {
+		return zeroValue, ErrOutOfRange
+	}
+	diff, err := Subtract(f.Abs(), Fraction{numerator: q, denominator: scale}.normalize())
+	if err != nil {
+		return zeroValue, err
+	}
+	return diff.Abs(), nil
+}
+
+// ToFields splits the fraction into fields for schemas like the protobuf message
+// {int64 numerator; uint64 denominator; bool negative}. The numerator is the absolute value and the sign goes in
+// negative, so -3/4 returns 3, 4, true.
+//
+// exact is false when the numerator doesn't fit in an int64, the fields then hold the closest fraction whose
+// numerator does fit, saturating to math.MaxInt64/1 for values too big for any
+func (f Fraction) ToFields() (numerator int64, denominator uint64, negative bool, exact bool) {
+	if f.numerator <= math.MaxInt64 {
+		return int64(f.numerator), f.denominator, f.negative, true
+	}
+
+	// The closest fraction with a bounded numerator is the reciprocal of the closest one with a bounded denominator
+	approx := limitDenominator(Fraction{numerator: f.denominator, denominator: f.numerator}, math.MaxInt64)
+	if approx.isZero() {
+		return math.MaxInt64, 1, f.negative, false
+	}
+	return int64(approx.denominator), approx.numerator, f.negative, false
+}
+
+// FromFields builds a fraction back from the fields ToFields returns, reducing it. A negative numerator is also
+// accepted, the result is negative when exactly one of numerator < 0 and negative holds.
+//
+// It returns ErrZeroDenominator if denominator is 0
+func FromFields(numerator int64, denominator uint64, negative bool) (Fraction, error) {
+	f, err := New(numerator, denominator)
+	if err != nil {
+		return zeroValue, err
+	}
+	if negative {
+		f = f.Negate()
+	}
+	return f, nil
+}
+
+// FromDurationRatio returns the ratio between two durations as a fraction, 30 minutes over 2 hours returns 1/4
+//
+// It returns ErrDivideByZero if b is zero
+func FromDurationRatio(a, b time.Duration) (Fraction, error) {
+	if b == 0 {
+		return zeroValue, ErrDivideByZero
+	}
+	return New(int64(a), int64(b))
+}
+
+// ScaleDuration returns the duration d scaled by f, rounded to the nearest nanosecond (halves away from zero)
+//
+// The product is done in 128 bits so large durations don't overflow midway. If the result doesn't fit in a
+// time.Duration, it saturates to the biggest (or smallest) possible duration
+func (f Fraction) ScaleDuration(d time.Duration) time.Duration {
+	if f.numerator == 0 || d == 0 {
+		return 0
+	}
+
+	neg := f.negative != (d < 0)
+	limit := uint64(math.MaxInt64)
+	if neg {
+		limit++
+	}
+
+	hi, lo := bits.Mul64(uint64(abs(int64(d))), f.numerator)
+	if hi >= f.denominator {
+		return saturateDuration(neg)
+	}
+	q, r := bits.Div64(hi, lo, f.denominator)
+	if r >= f.denominator-r {
+		q++
+	}
+	if q > limit {
+		return saturateDuration(neg)
+	}
+
+	if neg {
+		return time.Duration(-q)
+	}
+	return time.Duration(q)
+}
+
+// saturateDuration returns the furthest duration in the given direction
+func saturateDuration(negative bool) time.Duration {
+	if negative {
+		return math.MinInt64
+	}
+	return math.MaxInt64
+}
+
+// Ticks returns the number of ticks of size 1/tickDen closest to f, round(f * tickDen) with halves rounded away
+// from zero, and whether that amount of ticks is exactly f. The product is done in 128 bits.
+//
+// If tickDen is 0 or the amount of ticks doesn't fit in an int64, it returns 0, false
+func (f Fraction) Ticks(tickDen uint64) (int64, bool) {
+	if tickDen == 0 {
+		return 0, false
+	}
+	ticks, ok := scaledRound(f, tickDen)
+	if !ok {
+		return 0, false
+	}
+	// f*tickDen is an integer only when the denominator divides tickDen, since f is simplified
+	return ticks, tickDen%f.denominator == 0
+}
+
+// FromTicks creates a fraction from an amount of ticks of size 1/tickDen, that is, ticks/tickDen
+//
+// It returns ErrZeroDenominator if tickDen is 0
+func FromTicks(ticks int64, tickDen uint64) (Fraction, error) {
+	return New(ticks, tickDen)
+}
@@ -0,0 +1,244 @@
+package fraction
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxDecimalPartsDigits bounds how many fractional digits DecimalParts looks at while searching for a period
+const maxDecimalPartsDigits = 1024
+
+// DigitsInBase expands the absolute value of f in the given base through long division
+//
+// intDigits holds the digits of the integer part (most significant first, [0] for values below one) and fracDigits
+// the digits after the point. The expansion stops as soon as it terminates, as soon as a repetition is found or
+// after maxDigits fractional digits, whichever comes first. When a repetition is found, fracDigits ends with exactly
+// one period and repeatStart is the index in fracDigits where that period begins, otherwise repeatStart is -1.
+// 1/6 in base 10 returns [0], [1, 6] and 1. The sign isn't part of the digits, use IsNegative() for it.
+//
+// It returns ErrInvalid if base < 2 or maxDigits < 0
+func (f Fraction) DigitsInBase(base int, maxDigits int) (intDigits []int, fracDigits []int, repeatStart int, err error) {
+	if base < 2 || maxDigits < 0 {
+		return nil, nil, -1, ErrInvalid
+	}
+
+	intDigits = uintDigits(f.numerator/f.denominator, uint64(base))
+	fracDigits, repeatStart = longDivision(f.numerator%f.denominator, f.denominator, uint64(base), maxDigits)
+	return intDigits, fracDigits, repeatStart, nil
+}
+
+// DecimalDigits lazily yields the digits after the decimal point of f as (position, digit) pairs, where position
+// is the decimal place starting at 1, so 1/8 yields (1, 1), (2, 2), (3, 5). Each digit is one step of long division
+// worked out when it's pulled, so a UI can keep asking for more without computing anything up front.
+//
+// Terminating expansions stop after their last non zero digit and integers yield nothing, while repeating ones
+// never stop on their own, so ranging over 1/7 has to break out of the loop. The sign and the integer part aren't
+// included, see DecimalParts or DigitsInBase for those
+func (f Fraction) DecimalDigits() iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		rem, den := f.numerator%f.denominator, f.denominator
+		for pos := 1; rem != 0; pos++ {
+			hi, lo := bits.Mul64(rem, 10)
+			digit, r := bits.Div64(hi, lo, den)
+			if !yield(pos, int(digit)) {
+				return
+			}
+			rem = r
+		}
+	}
+}
+
+// DecimalParts splits the decimal expansion of f into strings, the whole part, the digits after the point that
+// don't repeat and the repeating period, so that a UI can render 1/6 as "0.1" followed by an overlined "6".
+// 1/6 returns ("0", "1", "6") and 1/2 returns ("0", "5", ""). The sign goes on the whole part, so -1/6 returns
+// ("-0", "1", "6").
+//
+// Periods can be as long as the denominator, so only the first 1024 fractional digits are examined. complete is
+// false if the period didn't show up by then, like for 1/1033, in which case terminating holds those digits,
+// repeating is empty and together they're only the start of the expansion
+func (f Fraction) DecimalParts() (whole string, terminating string, repeating string, complete bool) {
+	whole = strconv.FormatUint(f.numerator/f.denominator, 10)
+	if f.negative {
+		whole = "-" + whole
+	}
+
+	digits, start := longDivision(f.numerator%f.denominator, f.denominator, 10, maxDecimalPartsDigits)
+	if start < 0 {
+		// Terminating expansions of 64-bit denominators have at most 64 digits, so hitting the limit means the
+		// division was cut short
+		if len(digits) == maxDecimalPartsDigits {
+			return whole, joinDigits(digits), "", false
+		}
+		start = len(digits)
+	}
+	return whole, joinDigits(digits[:start]), joinDigits(digits[start:]), true
+}
+
+// joinDigits writes decimal digits next to each other
+func joinDigits(digits []int) string {
+	var sb strings.Builder
+	for _, d := range digits {
+		sb.WriteByte(byte('0' + d))
+	}
+	return sb.String()
+}
+
+// ExpansionTerm is a single Coeff * base^Exp term of a positional expansion
+type ExpansionTerm = struct {
+	Coeff int
+	Exp   int
+}
+
+// BaseExpansionTerms writes f as a sum of Coeff * base^Exp terms, one per non zero digit of its expansion in the
+// given base, from the highest exponent down, so 5/4 in base 10 is 1*10^0 + 2*10^-1 + 5*10^-2. The coefficients
+// of negative values are negative, and zero has no terms.
+//
+// Non terminating expansions are cut after maxTerms terms. It returns ErrInvalid if base < 2 or maxTerms < 0
+func (f Fraction) BaseExpansionTerms(base int, maxTerms int) ([]ExpansionTerm, error) {
+	if base < 2 || maxTerms < 0 {
+		return nil, ErrInvalid
+	}
+
+	sign := 1
+	if f.negative {
+		sign = -1
+	}
+
+	terms := []ExpansionTerm{}
+	intDigits := uintDigits(f.numerator/f.denominator, uint64(base))
+	for i, d := range intDigits {
+		if d != 0 && len(terms) < maxTerms {
+			terms = append(terms, ExpansionTerm{Coeff: sign * d, Exp: len(intDigits) - 1 - i})
+		}
+	}
+
+	// Keep dividing past any repetition until enough terms are found. Every zero digit multiplies the remainder
+	// by base without reaching den, so there are fewer than log_base(den) <= 64 of them in a row while something
+	// is left, and each term costs at most 64 steps
+	rem, den, b := f.numerator%f.denominator, f.denominator, uint64(base)
+	for exp := -1; rem != 0 && len(terms) < maxTerms; exp-- {
+		hi, lo := bits.Mul64(rem, b)
+		digit, r := bits.Div64(hi, lo, den)
+		if digit != 0 {
+			terms = append(terms, ExpansionTerm{Coeff: sign * int(digit), Exp: exp})
+		}
+		rem = r
+	}
+	return terms, nil
+}
+
+// uintDigits returns the digits of n in the given base, most significant first
+func uintDigits(n, base uint64) []int {
+	if n == 0 {
+		return []int{0}
+	}
+
+	var digits []int
+	for ; n > 0; n /= base {
+		digits = append(digits, int(n%base))
+	}
+	slices.Reverse(digits)
+	return digits
+}
+
+// longDivision produces the digits of rem/den (with rem < den) in the given base, up to maxDigits of them.
+// It stops early when the division terminates or when a remainder repeats, in which case it returns the index
+// where the repeating period starts, otherwise the returned index is -1
+func longDivision(rem, den, base uint64, maxDigits int) ([]int, int) {
+	var digits []int
+	seen := map[uint64]int{}
+
+	for rem != 0 && len(digits) < maxDigits {
+		if start, ok := seen[rem]; ok {
+			return digits, start
+		}
+		seen[rem] = len(digits)
+
+		// rem < den, so rem*base / den always fits in 64 bits
+		hi, lo := bits.Mul64(rem, base)
+		digit, r := bits.Div64(hi, lo, den)
+		digits = append(digits, int(digit))
+		rem = r
+	}
+
+	// The loop can run out of digits right as the period closes
+	if start, ok := seen[rem]; ok && rem != 0 {
+		return digits, start
+	}
+	return digits, -1
+}
+
+// BalancedBaseDigits expands f in a balanced base, where the digits go from -(base-1)/2 to (base-1)/2 instead of
+// 0 to base-1, like balanced ternary with its digits -1, 0 and 1. Negative values don't need a sign, their digits
+// are just negated, and intDigits and fracDigits hold the digits before and after the point, most significant
+// first ([0] for a zero integer part). 1/3 in base 3 is [0] and [1], and 2 is [1] and [-1] (3 - 1).
+//
+// The integer part is f rounded to the nearest integer, so the fractional digits always add up to a value between
+// -1/2 and 1/2. Halves have two infinite expansions, the one with positive digits is used for positive values,
+// 1/2 in base 3 being 0.111... The expansion stops when it terminates or after maxDigits fractional digits.
+//
+// It returns ErrInvalid if base is even or below 3, or if maxDigits is negative
+func (f Fraction) BalancedBaseDigits(base int, maxDigits int) (intDigits []int, fracDigits []int, err error) {
+	if base < 3 || base%2 == 0 || maxDigits < 0 {
+		return nil, nil, ErrInvalid
+	}
+	b, den := uint64(base), f.denominator
+
+	// f = ±(whole + m/den), move whole to the nearest integer so that m/den stays within 1/2
+	whole, m := f.numerator/den, f.numerator%den
+	fracNeg := f.negative
+	if m > den-m {
+		// den is at least 2 here, so whole + 1 can't overflow
+		whole, m, fracNeg = whole+1, den-m, !f.negative
+	}
+
+	intDigits = balancedDigits(whole, b)
+	if f.negative {
+		for i := range intDigits {
+			intDigits[i] = -intDigits[i]
+		}
+	}
+
+	for m != 0 && len(fracDigits) < maxDigits {
+		// m/den <= 1/2, so m*base/den <= base/2 and the quotient is a valid digit
+		hi, lo := bits.Mul64(m, b)
+		q, rem := bits.Div64(hi, lo, den)
+		digitNeg := fracNeg
+		if rem > den-rem {
+			// Round up and carry on with what's left, which now has the opposite sign
+			q, rem, fracNeg = q+1, den-rem, !fracNeg
+		}
+		digit := int(q)
+		if digitNeg {
+			digit = -digit
+		}
+		fracDigits = append(fracDigits, digit)
+		m = rem
+	}
+	return intDigits, fracDigits, nil
+}
+
+// balancedDigits returns the digits of n in the balanced version of the odd base, most significant first
+func balancedDigits(n, base uint64) []int {
+	if n == 0 {
+		return []int{0}
+	}
+
+	half := base / 2
+	var digits []int
+	for n > 0 {
+		d := n % base
+		n /= base
+		if d > half {
+			digits = append(digits, int(d)-int(base))
+			n++
+		} else {
+			digits = append(digits, int(d))
+		}
+	}
+	slices.Reverse(digits)
+	return digits
+}
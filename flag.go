@@ -0,0 +1,35 @@
+package fraction
+
+import "fmt"
+
+// FractionFlag lets a fraction be used as a command line flag, it implements flag.Value
+//
+//	ratio := fraction.One()
+//	flag.Var(&fraction.FractionFlag{Fraction: &ratio}, "ratio", "a ratio like 3/4 or 0.75")
+//
+// Values are read with Parse, so both fraction and decimal forms are accepted. If the embedded pointer is nil,
+// Set allocates a new fraction for it
+type FractionFlag struct {
+	*Fraction
+}
+
+// String returns the current value of the flag, or an empty string if there's no fraction behind it
+func (ff FractionFlag) String() string {
+	if ff.Fraction == nil {
+		return ""
+	}
+	return ff.Fraction.String()
+}
+
+// Set parses the flag value and stores it, returning a descriptive error if it isn't a valid fraction
+func (ff *FractionFlag) Set(s string) error {
+	v, err := Parse(s)
+	if err != nil {
+		return fmt.Errorf("invalid fraction %q: %w", s, err)
+	}
+	if ff.Fraction == nil {
+		ff.Fraction = new(Fraction)
+	}
+	*ff.Fraction = v
+	return nil
+}
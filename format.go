@@ -0,0 +1,198 @@
+package fraction
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Format selects how Render writes a fraction
+type Format int
+
+const (
+	// FormatImproper is the "n/d" form of String(), 3/2 renders as "3/2"
+	FormatImproper Format = iota
+	// FormatMixed writes a whole part and a proper fraction, 3/2 renders as "1 1/2"
+	FormatMixed
+	// FormatDecimal writes a decimal rounded to at most 6 places, 3/2 renders as "1.5" and 1/3 as "0.333333"
+	FormatDecimal
+	// FormatPercent writes a percentage rounded to at most 2 places, 3/2 renders as "150%" and 1/3 as "33.33%"
+	FormatPercent
+)
+
+// Render writes the fraction in the given format, see the Format constants for what each one looks like
+//
+// It returns ErrInvalid for an unknown format and can return ErrOutOfRange if the percentage overflows
+func (f Fraction) Render(format Format) (string, error) {
+	switch format {
+	case FormatImproper:
+		return f.String(), nil
+	case FormatMixed:
+		return f.mixedString(), nil
+	case FormatDecimal:
+		return f.roundedDecimalString(6), nil
+	case FormatPercent:
+		pct, err := Multiply(f, NewI(100))
+		if err != nil {
+			return "", err
+		}
+		return pct.roundedDecimalString(2) + "%", nil
+	}
+	return "", ErrInvalid
+}
+
+// mixedString writes the fraction as a whole part followed by a proper fraction, like "-1 1/2". Values below one
+// only have the fraction and integers only have the whole part
+func (f Fraction) mixedString() string {
+	whole, rem := f.numerator/f.denominator, f.numerator%f.denominator
+	if whole == 0 || rem == 0 {
+		return f.String()
+	}
+
+	var str strings.Builder
+	if f.negative {
+		str.WriteRune('-')
+	}
+	str.WriteString(strconv.FormatUint(whole, 10))
+	str.WriteRune(' ')
+	str.WriteString(strconv.FormatUint(rem, 10))
+	str.WriteRune('/')
+	str.WriteString(strconv.FormatUint(f.denominator, 10))
+	return str.String()
+}
+
+// CompactString returns the shortest clean representation of the fraction
+//
+// The rules are the following:
+//   - Whole numbers always use the integer form, 2 returns "2"
+//   - Terminating decimals use the decimal form as long as it isn't longer than the "n/d" form, so 1/2 returns
+//     "0.5" and 3/10 returns "0.3", but 1/8 returns "1/8" since "0.125" is longer
+//   - Anything else uses the "n/d" form, 1/3 returns "1/3"
+func (f Fraction) CompactString() string {
+	if f.denominator == 1 {
+		return f.String()
+	}
+
+	k, ok := f.DecimalExponent()
+	// The decimal form has at least k digits after the dot, a leading digit and the dot itself
+	if !ok || k+2 > f.DisplayWidth() {
+		return f.String()
+	}
+
+	if dec := f.decimalString(k); len(dec) <= f.DisplayWidth() {
+		return dec
+	}
+	return f.String()
+}
+
+// Key returns a canonical string for the fraction meant for map[string]T keys, the sign byte followed by the
+// numerator and the denominator, so 1/2 is "+:1:2", -2 is "-:2:1" and zero is "+:0:1". Fractions are always
+// kept reduced with a single zero, so equal values always get the same key and different ones never do.
+// It skips the formatting String() goes through, so it's cheaper to build
+func (f Fraction) Key() string {
+	var buf [44]byte
+	b := append(buf[:0], '+', ':')
+	if f.negative {
+		b[0] = '-'
+	}
+	b = strconv.AppendUint(b, f.numerator, 10)
+	b = append(b, ':')
+	b = strconv.AppendUint(b, f.denominator, 10)
+	return string(b)
+}
+
+// decimalString formats the fraction with exactly k decimal places, it assumes the denominator divides 10^k
+func (f Fraction) decimalString(k int) string {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(k)), nil)
+	scale.Quo(scale, new(big.Int).SetUint64(f.denominator))
+	digits := scale.Mul(scale, new(big.Int).SetUint64(f.numerator)).String()
+
+	// Pad with zeros so there's always a digit before the dot
+	if len(digits) <= k {
+		digits = strings.Repeat("0", k-len(digits)+1) + digits
+	}
+
+	var str strings.Builder
+	if f.negative && f.numerator != 0 {
+		str.WriteRune('-')
+	}
+	str.WriteString(digits[:len(digits)-k])
+	if k > 0 {
+		str.WriteRune('.')
+		str.WriteString(digits[len(digits)-k:])
+	}
+	return str.String()
+}
+
+// roundedDecimalString formats the fraction rounded (halves away from zero) to at most the given number of decimal
+// places, trailing zeros after the dot are removed
+func (f Fraction) roundedDecimalString(places int) string {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+	num := new(big.Int).Mul(new(big.Int).SetUint64(f.numerator), scale)
+	den := new(big.Int).SetUint64(f.denominator)
+
+	// round(num/den) = floor((2*num + den) / (2*den))
+	num.Lsh(num, 1).Add(num, den)
+	digits := num.Quo(num, den.Lsh(den, 1)).String()
+	if digits == "0" {
+		return "0"
+	}
+
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+	whole, decimals := digits[:len(digits)-places], strings.TrimRight(digits[len(digits)-places:], "0")
+
+	var str strings.Builder
+	if f.negative {
+		str.WriteRune('-')
+	}
+	str.WriteString(whole)
+	if decimals != "" {
+		str.WriteRune('.')
+		str.WriteString(decimals)
+	}
+	return str.String()
+}
+
+// ScientificString writes the fraction in scientific notation rounded to sigFigs significant figures, with
+// halves rounded away from zero and trailing zeros of the mantissa removed, so 1500 is "1.5e3", 1/30 with 3
+// figures is "3.33e-2" and 7 is "7e0". The exponent comes from OrderOfMagnitude, so it's exact and not a float
+// logarithm. Zero is "0" and a sigFigs below 1 is treated as 1
+func (f Fraction) ScientificString(sigFigs int) string {
+	if f.numerator == 0 {
+		return "0"
+	}
+	sigFigs = max(sigFigs, 1)
+
+	// mantissa = round(|f| * 10^(sigFigs-1-k)), which has sigFigs digits unless it rounds up to 10^sigFigs
+	k := f.OrderOfMagnitude()
+	num := new(big.Int).SetUint64(f.numerator)
+	den := new(big.Int).SetUint64(f.denominator)
+	if shift := sigFigs - 1 - k; shift >= 0 {
+		num.Mul(num, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(shift)), nil))
+	} else {
+		den.Mul(den, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-shift)), nil))
+	}
+
+	// round(num/den) = floor((2*num + den) / (2*den))
+	num.Lsh(num, 1).Add(num, den)
+	digits := num.Quo(num, den.Lsh(den, 1)).String()
+	if len(digits) > sigFigs {
+		digits = digits[:sigFigs]
+		k++
+	}
+
+	var str strings.Builder
+	if f.negative {
+		str.WriteRune('-')
+	}
+	str.WriteString(digits[:1])
+	if rest := strings.TrimRight(digits[1:], "0"); rest != "" {
+		str.WriteRune('.')
+		str.WriteString(rest)
+	}
+	str.WriteRune('e')
+	str.WriteString(strconv.Itoa(k))
+	return str.String()
+}
@@ -10,6 +10,7 @@ import (
 	"math/bits"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 // Fraction represents a fraction. It is an immutable type.
@@ -233,18 +234,14 @@ func Parse(s string) (Fraction, error) {
 
 // ParseDecimal translates the string of a decimal number into a fraction
 // -0.3 returns -3/10
-// 0.2 returns 2/10
+// 0.2 returns 1/5
 // 2.5 returns 5/2
 func ParseDecimal(s string) (Fraction, error) {
-	// Trim leftover spaces
-	str := strings.TrimSpace(s)
-	negative := false
+	// Trim leftover spaces and get the sign
+	str, negative := cutSign(s)
 
-	// Get the sign
-	if str[0] == '-' {
-		negative = true
-		// Remove negative sign
-		str = str[1:]
+	if str == "" {
+		return zeroValue, errors.New("empty decimal")
 	}
 
 	// Now get both parts of the number
@@ -254,51 +251,44 @@ func ParseDecimal(s string) (Fraction, error) {
 		return zeroValue, errors.New("too much dots")
 	}
 
-	var lhs uint64
-
 	if parts[0] == "" {
 		return zeroValue, errors.New("no leading numeral at left hand side of decimal")
 	}
 
-	fmt.Println("Parsing LHS")
 	lhs, err := strconv.ParseUint(parts[0], 10, 64)
-
 	if err != nil {
 		return zeroValue, err
 	}
 
-	fmt.Println("LHS Parsed")
-
 	if len(parts) == 1 {
-
-		fmt.Println("Only numerator")
-		return Fraction{
-			numerator:   lhs,
-			denominator: 1,
-			negative:    negative,
-		}, err
+		// normalize keeps "-0" as the canonical +0
+		return Fraction{numerator: lhs, denominator: 1, negative: negative}.normalize(), nil
 	}
 
-	fmt.Println("Parsing RHS")
 	rhs, err := strconv.ParseUint(parts[1], 10, 64)
 	if err != nil {
 		return zeroValue, err
 	}
 
-	fmt.Println("RHS Parsed")
-
-	fmt.Println("Getting fractional...")
-	fracpart, err := New(rhs, uint64(math.Pow(10, float64(getintsize(rhs)))))
-	fmt.Printf("Fractional obtained: %s\n", fracpart.String())
+	// The denominator comes from the digits written, not from rhs, so the zeros in "0.05" aren't lost
+	if len(parts[1]) > 19 {
+		return zeroValue, ErrOutOfRange
+	}
+	fracpart, err := New(rhs, pow10(len(parts[1])))
 	if err != nil {
 		return zeroValue, err
 	}
 
-	return NewI(lhs).Add(fracpart)
+	res, err := NewI(lhs).Add(fracpart)
+	if negative {
+		res = res.Negate()
+	}
+	return res, err
 }
 
-// Fast Addition module when both fractions denominators are the same
-func fastAdd(f1, f2 Fraction) (Fraction, error) {
+// Fast Addition module when both fractions denominators are the same, the result isn't reduced and ok is false if
+// it overflows
+func fastAdd(f1, f2 Fraction) (sum Fraction, ok bool) {
 	a := f1.numerator
 	b := f2.numerator
 
@@ -306,7 +296,7 @@ func fastAdd(f1, f2 Fraction) (Fraction, error) {
 	var neg bool
 	if f1.negative == f2.negative {
 		if a > math.MaxUint64-b {
-			return zeroValue, ErrOutOfRange
+			return zeroValue, false
 		}
 		num = a + b
 		neg = f1.negative
@@ -320,7 +310,7 @@ func fastAdd(f1, f2 Fraction) (Fraction, error) {
 		}
 	}
 
-	return Fraction{numerator: num, denominator: f1.denominator, negative: neg}.normalize(), nil
+	return Fraction{numerator: num, denominator: f1.denominator, negative: neg}, true
 }
 
 // Add adds both fractions and returns the result.
@@ -334,6 +324,16 @@ func Add(f1, f2 Fraction) (Fraction, error) {
 		return f1.normalize(), nil
 	}
 
+	sum, ok := addUnreduced(f1, f2)
+	if !ok {
+		return zeroValue, ErrOutOfRange
+	}
+	return sum.normalize(), nil
+}
+
+// addUnreduced adds two non zero fractions over the least common multiple of their denominators without reducing
+// the result, ok is false if anything overflows. Add and WillAddOverflow share it so they can't disagree
+func addUnreduced(f1, f2 Fraction) (sum Fraction, ok bool) {
 	if f1.denominator == f2.denominator {
 		return fastAdd(f1, f2)
 	}
@@ -345,7 +345,7 @@ func Add(f1, f2 Fraction) (Fraction, error) {
 
 	// check a = n1*scale1, b = n2*scale2
 	if f1.numerator > math.MaxUint64/scale1 || f2.numerator > math.MaxUint64/scale2 {
-		return zeroValue, ErrOutOfRange
+		return zeroValue, false
 	}
 	a := f1.numerator * scale1
 	b := f2.numerator * scale2
@@ -353,7 +353,7 @@ func Add(f1, f2 Fraction) (Fraction, error) {
 	// den = (d1/g) * d2
 	den := f1.denominator / g
 	if den > math.MaxUint64/f2.denominator {
-		return zeroValue, ErrOutOfRange
+		return zeroValue, false
 	}
 	den *= f2.denominator
 
@@ -361,7 +361,7 @@ func Add(f1, f2 Fraction) (Fraction, error) {
 	var neg bool
 	if f1.negative == f2.negative {
 		if a > math.MaxUint64-b { // sum overflow
-			return zeroValue, ErrOutOfRange
+			return zeroValue, false
 		}
 		num = a + b
 		neg = f1.negative
@@ -374,7 +374,7 @@ func Add(f1, f2 Fraction) (Fraction, error) {
 			neg = f2.negative
 		}
 	}
-	return (Fraction{numerator: num, denominator: den, negative: neg}).normalize(), nil
+	return Fraction{numerator: num, denominator: den, negative: neg}, true
 }
 
 // Negates a fraction, turning it from negative to positive or positive to negative
@@ -419,6 +419,16 @@ func Multiply(f1, f2 Fraction) (Fraction, error) {
 		return zeroValue, nil
 	}
 
+	product, ok := multiplyCancelled(f1, f2)
+	if !ok {
+		return zeroValue, ErrOutOfRange
+	}
+	return product.normalize(), nil
+}
+
+// multiplyCancelled multiplies two non zero fractions after cross-cancelling them, ok is false if the product
+// overflows. Multiply and WillMultiplyOverflow share it so they can't disagree
+func multiplyCancelled(f1, f2 Fraction) (product Fraction, ok bool) {
 	// cross-cancel to reduce overflow risk
 	g1 := gcd(f1.numerator, f2.denominator)
 	g2 := gcd(f2.numerator, f1.denominator)
@@ -429,13 +439,31 @@ func Multiply(f1, f2 Fraction) (Fraction, error) {
 	d1 := f1.denominator / g2
 
 	if n1 > math.MaxUint64/n2 || d1 > math.MaxUint64/d2 {
-		return zeroValue, ErrOutOfRange
+		return zeroValue, false
 	}
-	num := n1 * n2
-	den := d1 * d2
-	neg := f1.negative != f2.negative
+	return Fraction{numerator: n1 * n2, denominator: d1 * d2, negative: f1.negative != f2.negative}, true
+}
 
-	return (Fraction{numerator: num, denominator: den, negative: neg}).normalize(), nil
+// WillAddOverflow reports whether Add(f1, f2) would return ErrOutOfRange, without performing the addition
+//
+// It runs the exact same checks as Add, so it's useful to decide beforehand if a bigger representation is needed
+func WillAddOverflow(f1, f2 Fraction) bool {
+	if f1.isZero() || f2.isZero() {
+		return false
+	}
+	_, ok := addUnreduced(f1, f2)
+	return !ok
+}
+
+// WillMultiplyOverflow reports whether Multiply(f1, f2) would return ErrOutOfRange, without performing the multiplication
+//
+// Like Multiply, it cross-cancels first, so only products that are truly out of range are reported
+func WillMultiplyOverflow(f1, f2 Fraction) bool {
+	if f1.numerator == 0 || f2.numerator == 0 {
+		return false
+	}
+	_, ok := multiplyCancelled(f1, f2)
+	return !ok
 }
 
 func Divide(f1 Fraction, f2 Fraction) (Fraction, error) {
@@ -471,6 +499,50 @@ func (f1 Fraction) Subtract(f2 Fraction) (Fraction, error) {
 	return Subtract(f1, f2)
 }
 
+// AddInt adds an integer to the fraction, adjusting the numerator directly since the denominator stays the same
+//
+// Can return ErrOutOfRange if the resulting numerator overflows the uint64 limit
+func (f1 Fraction) AddInt(k int64) (Fraction, error) {
+	return addInt(f1, uint64(abs(k)), k < 0)
+}
+
+// SubInt subtracts an integer from the fraction, adjusting the numerator directly since the denominator stays the same
+//
+// Can return ErrOutOfRange if the resulting numerator overflows the uint64 limit
+func (f1 Fraction) SubInt(k int64) (Fraction, error) {
+	return addInt(f1, uint64(abs(k)), k > 0)
+}
+
+// addInt adds the integer with magnitude k and the given sign to f. Since gcd(n + k*d, d) == gcd(n, d), the
+// result is already simplified
+func addInt(f Fraction, k uint64, negative bool) (Fraction, error) {
+	// k*d is done in 128 bits, it may not fit by itself but still cancel out with the numerator
+	hi, lo := bits.Mul64(k, f.denominator)
+
+	var num, carry uint64
+	var neg bool
+	if f.negative == negative {
+		num, carry = bits.Add64(lo, f.numerator, 0)
+		hi += carry
+		neg = negative
+	} else if hi != 0 || lo >= f.numerator {
+		num, carry = bits.Sub64(lo, f.numerator, 0)
+		hi -= carry
+		neg = negative
+	} else {
+		num = f.numerator - lo
+		neg = f.negative
+	}
+
+	if hi != 0 {
+		return zeroValue, ErrOutOfRange
+	}
+	if num == 0 {
+		return zeroValue, nil
+	}
+	return Fraction{numerator: num, denominator: f.denominator, negative: neg}, nil
+}
+
 // Multiply multiplies both fractions and returns the result.
 //
 // It returns ErrDivideByZero if it tries to divide by a fraction with value 0.
@@ -511,6 +583,18 @@ func (f1 Fraction) Invert() (Fraction, error) {
 	return Fraction{numerator: f1.denominator, denominator: f1.numerator, negative: f1.negative}, nil
 }
 
+// Inverses returns both the additive inverse (-f) and the multiplicative inverse (1/f) of the fraction.
+// Every fraction has an additive inverse, but zero has no multiplicative one, so for zero
+// the additive inverse is still returned alongside ErrDivideByZero
+func (f1 Fraction) Inverses() (additive Fraction, multiplicative Fraction, err error) {
+	additive = f1.Negate()
+	if f1.numerator == 0 {
+		return additive, zeroValue, ErrDivideByZero
+	}
+	multiplicative, _ = f1.Invert()
+	return additive, multiplicative, nil
+}
+
 // Returns a fraction without its negative component
 func (f Fraction) Abs() Fraction {
 	return Fraction{
@@ -530,6 +614,33 @@ func (f1 Fraction) Float64() float64 {
 	return val
 }
 
+// OrderOfMagnitude returns the integer k such that 10^k <= |f| < 10^(k+1), so 1/1000 returns -3 and 150 returns 2.
+//
+// It's computed exactly from the digit counts instead of a float logarithm. Zero has no order of magnitude,
+// so math.MinInt is returned for it
+func (f1 Fraction) OrderOfMagnitude() int {
+	if f1.numerator == 0 {
+		return math.MinInt
+	}
+
+	// The answer is either the digit difference or one less than it
+	k := int(getintsize(f1.numerator)) - int(getintsize(f1.denominator))
+
+	// Check whether n >= d * 10^k (or n * 10^-k >= d when k is negative), |k| <= 19 so 10^|k| fits
+	if k >= 0 {
+		hi, lo := bits.Mul64(f1.denominator, pow10(k))
+		if cmp128(0, f1.numerator, hi, lo) < 0 {
+			return k - 1
+		}
+	} else {
+		hi, lo := bits.Mul64(f1.numerator, pow10(-k))
+		if cmp128(hi, lo, 0, f1.denominator) < 0 {
+			return k - 1
+		}
+	}
+	return k
+}
+
 // Denominator returns the fraction denominator.
 func (f1 Fraction) Denominator() uint64 {
 	return f1.denominator
@@ -570,6 +681,25 @@ func (f1 Fraction) String() string {
 	return str.String()
 }
 
+// DisplayWidth returns the number of runes String() would produce, sign and slash included.
+//
+// It's computed from the digit counts so no string has to be built, useful for sizing columns before rendering
+func (f1 Fraction) DisplayWidth() int {
+	if f1.numerator == 0 {
+		return 1
+	}
+
+	width := int(getintsize(f1.numerator))
+	if f1.negative {
+		width++
+	}
+	if f1.denominator != 1 {
+		width += 1 + int(getintsize(f1.denominator))
+	}
+
+	return width
+}
+
 // Cmp returns -1 if a<b, 0 if a==b, +1 if a>b.
 func Cmp(f1 Fraction, f2 Fraction) int {
 	// Fast path: equal zeros (your invariant ensures canonical 0/1/positive).
@@ -615,23 +745,24 @@ func (f Fraction) LessEq(g Fraction) bool    { return f.Cmp(g) <= 0 }
 func (f Fraction) Greater(g Fraction) bool   { return f.Cmp(g) > 0 }
 func (f Fraction) GreaterEq(g Fraction) bool { return f.Cmp(g) >= 0 }
 
+// Free function forms of the comparators, handy to pass around as predicates
+
+func Less(f1, f2 Fraction) bool      { return Cmp(f1, f2) < 0 }
+func LessEq(f1, f2 Fraction) bool    { return Cmp(f1, f2) <= 0 }
+func Greater(f1, f2 Fraction) bool   { return Cmp(f1, f2) > 0 }
+func GreaterEq(f1, f2 Fraction) bool { return Cmp(f1, f2) >= 0 }
+func NotEqual(f1, f2 Fraction) bool  { return !Equal(f1, f2) }
+
 // ParseFracString a string to a fraction
 // This can return ErrInvalid if parsing was unsuccesful or ErrZeroDenominator if the denominator is, well, zero
 func ParseFracString(str string) (Fraction, error) {
-	s := strings.TrimSpace(str)
+	s, sign := cutSign(str)
 
 	if s == "" {
-		return zeroValue, errors.New("empty fraction")
-	}
-
-	sign := false
-	if s[0] == '-' {
-		sign = true
-		s = strings.TrimSpace(s[1:])
-
-		if s == "" {
+		if sign {
 			return zeroValue, errors.New("no leading numeral (no numbers after sign)")
 		}
+		return zeroValue, errors.New("empty fraction")
 	}
 
 	parts := strings.Split(s, "/")
@@ -671,6 +802,35 @@ func ParseFracString(str string) (Fraction, error) {
 	return f.normalize(), nil
 }
 
+// normalizeSign replaces a leading Unicode minus sign (U+2212) or en dash (U+2013), common in text pasted from
+// word processors, with an ASCII '-'. Dashes anywhere else are left alone so they're still rejected
+func normalizeSign(s string) string {
+	for _, dash := range []string{"\u2212", "\u2013"} {
+		if rest, ok := strings.CutPrefix(s, dash); ok {
+			return "-" + rest
+		}
+	}
+	return s
+}
+
+// cutSign is the shared sign tokenizer of the parsers. It trims the surrounding whitespace, takes off a leading
+// minus sign (ASCII or Unicode, see normalizeSign) and any whitespace right after it, so "-3/4", "- 3/4" and
+// " - 3 / 4 " all return a negative flag and the rest of the number. Only one sign is taken, so "--3" keeps a '-'
+// in rest and is rejected later by the number parsing
+func cutSign(s string) (rest string, negative bool) {
+	return cutLeadingSign(strings.TrimSpace(s))
+}
+
+// cutLeadingSign is cutSign without trimming the end of s. rest is always a suffix of s, so ParsePrefix, which
+// only reads the start of s, knows how much of it the sign took
+func cutLeadingSign(s string) (rest string, negative bool) {
+	s = normalizeSign(strings.TrimLeftFunc(s, unicode.IsSpace))
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		return strings.TrimLeftFunc(rest, unicode.IsSpace), true
+	}
+	return s, false
+}
+
 // Normalizes (simplifies) a fraction
 func (f Fraction) normalize() Fraction {
 	if f.numerator == 0 {
@@ -718,6 +878,15 @@ func cmp128(xhi, xlo, yhi, ylo uint64) int {
 	return 0
 }
 
+// pow10 returns 10^k, it assumes 0 <= k <= 19 so the result fits in an uint64
+func pow10(k int) uint64 {
+	p := uint64(1)
+	for range k {
+		p *= 10
+	}
+	return p
+}
+
 func getintsize(i uint64) uint8 {
 	if i == 0 {
 		return 1
@@ -0,0 +1,147 @@
+package fraction
+
+import "slices"
+
+// Slope returns the slope of the line going through (x1, y1) and (x2, y2), that is (y2 - y1) / (x2 - x1)
+//
+// It returns ErrDivideByZero if x1 == x2 and can return ErrOutOfRange if any of the steps overflow
+func Slope(x1, y1, x2, y2 Fraction) (Fraction, error) {
+	dx, err := Subtract(x2, x1)
+	if err != nil {
+		return zeroValue, err
+	}
+	if dx.isZero() {
+		return zeroValue, ErrDivideByZero
+	}
+	return Start(y2).Sub(y1).Div(dx).Result()
+}
+
+// InterpolateLinear returns the y value at x of the line going through (x1, y1) and (x2, y2)
+//
+// x doesn't have to be between x1 and x2, values outside extrapolate the same line.
+// It returns ErrDivideByZero if x1 == x2 and can return ErrOutOfRange if any of the steps overflow
+func InterpolateLinear(x, x1, y1, x2, y2 Fraction) (Fraction, error) {
+	m, err := Slope(x1, y1, x2, y2)
+	if err != nil {
+		return zeroValue, err
+	}
+	return Start(x).Sub(x1).Mult(m).Sum(y1).Result()
+}
+
+// InverseLerp returns where value sits between lo and hi, that is (value - lo) / (hi - lo), so
+// InverseLerp(3/4, 1/2, 1) is 1/2. Values outside [lo, hi] give results outside [0, 1].
+//
+// It returns ErrDivideByZero if lo == hi and can return ErrOutOfRange if any of the steps overflow
+func InverseLerp(value, lo, hi Fraction) (Fraction, error) {
+	span, err := Subtract(hi, lo)
+	if err != nil {
+		return zeroValue, err
+	}
+	if span.isZero() {
+		return zeroValue, ErrDivideByZero
+	}
+	return Start(value).Sub(lo).Div(span).Result()
+}
+
+// Midpoint returns the exact average of a and b, (a + b) / 2, so Midpoint(1/3, 1/2) is 5/12.
+//
+// Don't confuse it with the mediant (a.num + b.num) / (a.den + b.den), which also lies between a and b but isn't
+// halfway: the mediant of 1/3 and 1/2 is 2/5. The midpoint is what bisection needs to halve an interval.
+//
+// It can return ErrOutOfRange if the sum or the halving overflow
+func Midpoint(a, b Fraction) (Fraction, error) {
+	return Start(a).Sum(b).Div(NewI(2)).Result()
+}
+
+// Bisect looks for a root of f between lo and hi by repeatedly halving the interval, keeping the half where f
+// changes sign. It returns the midpoint of the interval left after the given number of iterations, or right away
+// the point where f is exactly zero if one is hit (lo and hi included). Every iteration halves the interval, so
+// the result is within (hi - lo) / 2^(iterations+1) of a root, and denominators grow accordingly.
+//
+// It returns ErrInvalid if iterations is negative or f(lo) and f(hi) don't have opposite signs. Errors coming
+// from f are returned as they are and ErrOutOfRange can be returned if the midpoints overflow
+func Bisect(f func(Fraction) (Fraction, error), lo, hi Fraction, iterations int) (Fraction, error) {
+	if iterations < 0 {
+		return zeroValue, ErrInvalid
+	}
+
+	flo, err := f(lo)
+	if err != nil {
+		return zeroValue, err
+	}
+	if flo.isZero() {
+		return lo, nil
+	}
+	fhi, err := f(hi)
+	if err != nil {
+		return zeroValue, err
+	}
+	if fhi.isZero() {
+		return hi, nil
+	}
+	if flo.negative == fhi.negative {
+		return zeroValue, ErrInvalid
+	}
+
+	for range iterations {
+		mid, err := Midpoint(lo, hi)
+		if err != nil {
+			return zeroValue, err
+		}
+		fmid, err := f(mid)
+		if err != nil {
+			return zeroValue, err
+		}
+		if fmid.isZero() {
+			return mid, nil
+		}
+		if fmid.negative == flo.negative {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return Midpoint(lo, hi)
+}
+
+// MapToRange maps f from the range [srcLo, srcHi] onto [dstLo, dstHi], that is
+// dstLo + (f - srcLo) / (srcHi - srcLo) * (dstHi - dstLo), so 1/2 from [0, 1] onto [0, 100] is 50. Values outside
+// the source range land outside the destination range and reversed ranges flip the direction. Everything stays
+// exact, so chaining remaps doesn't pile up rounding errors.
+//
+// It returns ErrDivideByZero if srcLo == srcHi and can return ErrOutOfRange if any of the steps overflow
+func (f Fraction) MapToRange(srcLo, srcHi, dstLo, dstHi Fraction) (Fraction, error) {
+	t, err := InverseLerp(f, srcLo, srcHi)
+	if err != nil {
+		return zeroValue, err
+	}
+	return Start(dstHi).Sub(dstLo).Mult(t).Sum(dstLo).Result()
+}
+
+// PiecewiseLinear evaluates the table of breakpoints (xs[i], ys[i]) at x, interpolating linearly between the two
+// breakpoints around it, so with the table (0, 0), (1, 2), (3, 3) the value at 2 is 5/2. The segment is found
+// with a binary search and x outside [xs[0], xs[len-1]] is clamped to the first or last y.
+//
+// It returns ErrInvalid if the slices are empty, have different lengths or xs isn't strictly increasing, and can
+// return ErrOutOfRange if the interpolation overflows
+func PiecewiseLinear(xs, ys []Fraction, x Fraction) (Fraction, error) {
+	if len(xs) == 0 || len(xs) != len(ys) {
+		return zeroValue, ErrInvalid
+	}
+	for i := 1; i < len(xs); i++ {
+		if Cmp(xs[i-1], xs[i]) >= 0 {
+			return zeroValue, ErrInvalid
+		}
+	}
+
+	i, found := slices.BinarySearchFunc(xs, x, Cmp)
+	switch {
+	case found:
+		return ys[i], nil
+	case i == 0:
+		return ys[0], nil
+	case i == len(xs):
+		return ys[len(ys)-1], nil
+	}
+	return InterpolateLinear(x, xs[i-1], ys[i-1], xs[i], ys[i])
+}
@@ -0,0 +1,44 @@
+package fraction
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalSlice encodes the fractions as a JSON array of their canonical strings, the ones String() returns, so
+// [1/2, 3] becomes ["1/2","3"]. A nil slice is encoded as an empty array
+func MarshalSlice(fs []Fraction) ([]byte, error) {
+	strs := make([]string, len(fs))
+	for i, f := range fs {
+		strs[i] = f.String()
+	}
+	return json.Marshal(strs)
+}
+
+// UnmarshalSlice decodes a JSON array of fraction strings like the one MarshalSlice produces. Every element is read
+// with ParseFracString, so "3/4", "-3/4" and "3" are all accepted.
+//
+// Errors point at the element that failed, like `element 2 ("1/0"): denominator cannot be zero`, and wrap the
+// underlying error, which is ErrInvalid for elements that aren't strings. If data isn't a JSON array, the
+// encoding/json error is returned
+func UnmarshalSlice(data []byte) ([]Fraction, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	fs := make([]Fraction, len(raw))
+	for i, r := range raw {
+		// null would decode into an empty string without complaining
+		var s string
+		if len(r) == 0 || r[0] != '"' || json.Unmarshal(r, &s) != nil {
+			return nil, fmt.Errorf("element %d (%s): %w", i, r, ErrInvalid)
+		}
+		f, err := ParseFracString(s)
+		if err != nil {
+			return nil, fmt.Errorf("element %d (%q): %w", i, s, err)
+		}
+		fs[i] = f
+	}
+	return fs, nil
+}
@@ -0,0 +1,431 @@
+package fraction
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// TwoUnitFractions looks for a decomposition of the fraction as 1/a + 1/b with a <= b
+//
+// It uses the classic identity (a*n - d)(b*n - d) = d^2, so every candidate comes from a divisor of d^2.
+// When several decompositions exist, the one with the smallest a is returned, so 2/3 gives 1/2 + 1/6.
+// ok is false if the fraction isn't positive or if no decomposition fits in uint64
+//
+// The denominator is factored with Pollard's rho, which takes up to about 2^16 steps for 64-bit denominators, and
+// then every divisor of d^2 below d is tried. Those are few for most denominators but can reach the millions for
+// ones with a lot of small prime factors, like the product of the first 15 primes
+func (f Fraction) TwoUnitFractions() (a, b uint64, ok bool) {
+	if f.numerator == 0 || f.negative {
+		return 0, 0, false
+	}
+
+	n, d := f.numerator, f.denominator
+	hi, lo := bits.Mul64(d, d)
+
+	// x = a*n - d, only divisors x <= d are needed since a <= b
+	for _, x := range squareDivisors(d) {
+		if x > d {
+			break
+		}
+		// y = d^2 / x, which must fit in 64 bits for b to fit
+		if hi >= x {
+			continue
+		}
+		y, _ := bits.Div64(hi, lo, x)
+
+		// a = (x + d) / n and b = (y + d) / n, the sums are kept in 128 bits since they can pass 2^64 while a and
+		// b still fit
+		a, ok := divSum(x, d, n)
+		if !ok {
+			continue
+		}
+		b, ok := divSum(y, d, n)
+		if !ok {
+			continue
+		}
+		return a, b, true
+	}
+
+	return 0, 0, false
+}
+
+// divSum returns (x + y) / n, ok is false if it isn't exact or doesn't fit in an uint64
+func divSum(x, y, n uint64) (q uint64, ok bool) {
+	sum, carry := bits.Add64(x, y, 0)
+	if carry >= n {
+		return 0, false
+	}
+	q, rem := bits.Div64(carry, sum, n)
+	return q, rem == 0
+}
+
+// AsUnitFraction returns the denominator d when f is exactly 1/d, so 1/7 returns 7, true and 1 returns 1, true.
+// Anything else, including negative values like -1/7, returns 0, false
+func (f Fraction) AsUnitFraction() (denominator uint64, ok bool) {
+	if f.numerator != 1 || f.negative {
+		return 0, false
+	}
+	return f.denominator, true
+}
+
+// IsMultipleOf reports whether f is an integer multiple of g, that is, if f/g is an integer
+//
+// Zero is a multiple of everything, so (0).IsMultipleOf(g) is always true. (3/4).IsMultipleOf(1/4) is true while
+// (3/4).IsMultipleOf(1/2) is false. It returns ErrDivideByZero if g is zero
+func (f Fraction) IsMultipleOf(g Fraction) (bool, error) {
+	if g.numerator == 0 {
+		return false, ErrDivideByZero
+	}
+	if f.numerator == 0 {
+		return true, nil
+	}
+
+	// (a/b) / (c/d) = (a*d) / (b*c), since both fractions are reduced, it's an integer only when b | d and c | a
+	return g.denominator%f.denominator == 0 && f.numerator%g.numerator == 0, nil
+}
+
+// DecimalExponent returns the smallest k such that f can be written exactly with k decimal places, that is, the
+// smallest k where the denominator divides 10^k. 3/8 returns 3 and integers return 0.
+// The bool is false when the decimal expansion doesn't terminate, like with 1/3
+func (f Fraction) DecimalExponent() (int, bool) {
+	d := f.denominator
+
+	twos := bits.TrailingZeros64(d)
+	d >>= twos
+
+	fives := 0
+	for d%5 == 0 {
+		d /= 5
+		fives++
+	}
+
+	if d != 1 {
+		return 0, false
+	}
+	return max(twos, fives), true
+}
+
+// IsDyadic reports whether the denominator is a power of two, in which case the value can be written exactly in
+// binary (as long as it fits in the float's precision), 3/8 and 5 are dyadic while 1/3 isn't
+func (f Fraction) IsDyadic() bool {
+	return bits.OnesCount64(f.denominator) == 1
+}
+
+// BinaryExponent returns k where the denominator is 2^k, the binary analogue of DecimalExponent. 3/8 returns 3
+// and integers return 0. The bool is false when the fraction isn't dyadic
+func (f Fraction) BinaryExponent() (int, bool) {
+	if !f.IsDyadic() {
+		return 0, false
+	}
+	return bits.TrailingZeros64(f.denominator), true
+}
+
+// IsInversePowerOfTwo returns k when f is exactly 1/2^k, so 1/8 returns 3, true and 1 returns 0, true. Unlike
+// IsDyadic, the numerator has to be 1, so 3/8 returns 0, false, and so do values above 1 like 2 or negative ones
+func (f Fraction) IsInversePowerOfTwo() (k uint, ok bool) {
+	if f.numerator != 1 || f.negative || !f.IsDyadic() {
+		return 0, false
+	}
+	return uint(bits.TrailingZeros64(f.denominator)), true
+}
+
+// ScaleToDenominator rewrites f over the least common multiple of its denominator and target, so it can be placed
+// on a shared grid with other values. It returns the value and the factor that both its numerator and
+// denominator have to be multiplied by to land on that grid.
+//
+// Since fractions are always kept simplified, the returned Fraction holds the same value as f, the scaled form is
+// Numerator()*factor over Denominator()*factor. (1/6).ScaleToDenominator(4) returns 1/6 and a factor of 2, which is
+// 2/12. It returns ErrZeroDenominator if target is 0 and ErrOutOfRange if the scaled numerator or denominator overflow
+func (f Fraction) ScaleToDenominator(target uint64) (Fraction, uint64, error) {
+	if target == 0 {
+		return zeroValue, 0, ErrZeroDenominator
+	}
+
+	l, ok := lcm(f.denominator, target)
+	if !ok {
+		return zeroValue, 0, ErrOutOfRange
+	}
+
+	factor := l / f.denominator
+	if f.numerator > math.MaxUint64/factor {
+		return zeroValue, 0, ErrOutOfRange
+	}
+	return f, factor, nil
+}
+
+// OnGrid reports whether f can be written exactly as k/gridDen, which happens when gridDen is a multiple of the
+// denominator. 1/4 is on a grid of 8 while 1/3 isn't. A gridDen of 0 always returns false
+func (f Fraction) OnGrid(gridDen uint64) bool {
+	return gridDen != 0 && gridDen%f.denominator == 0
+}
+
+// GridIndex returns the k for which f is exactly k/gridDen, so 1/4 on a grid of 8 returns 2, true.
+// The bool is false (and k is 0) when f isn't on the grid or k doesn't fit in an int64
+func (f Fraction) GridIndex(gridDen uint64) (int64, bool) {
+	if !f.OnGrid(gridDen) {
+		return 0, false
+	}
+
+	hi, k := bits.Mul64(f.numerator, gridDen/f.denominator)
+	if hi != 0 || k > math.MaxInt64 && !(f.negative && k == 1<<63) {
+		return 0, false
+	}
+	if f.negative {
+		return int64(-k), true
+	}
+	return int64(k), true
+}
+
+// InvertibleMod reports whether the denominator has an inverse modulo m, that is, gcd(denominator, m) == 1, in
+// which case the fraction has a value modulo m (see Mod). It's false for m == 0
+func (f Fraction) InvertibleMod(m uint64) bool {
+	return m != 0 && gcd(f.denominator, m) == 1
+}
+
+// Mod returns the value of the fraction modulo m, numerator * denominator^-1 mod m, as a number in [0, m).
+// 1/3 mod 7 is 5, since 3*5 = 15 = 1 mod 7, and negative fractions are mapped into the same range, so -1/3 mod 7
+// is 2. The bool is false when the value isn't defined, because m is 0 or the denominator isn't invertible
+func (f Fraction) Mod(m uint64) (uint64, bool) {
+	inv, ok := modInverse(f.denominator, m)
+	if !ok {
+		return 0, false
+	}
+
+	v := mulMod(f.numerator%m, inv, m)
+	if f.negative && v != 0 {
+		v = m - v
+	}
+	return v, true
+}
+
+// modInverse returns x such that a*x = 1 mod m, found with the extended Euclidean algorithm. The Bezout
+// coefficient is tracked modulo m so everything stays unsigned. The bool is false if a isn't invertible mod m
+func modInverse(a, m uint64) (uint64, bool) {
+	if m == 0 || gcd(a, m) != 1 {
+		return 0, false
+	}
+
+	var t, newT uint64 = 0, 1 % m
+	r, newR := m, a%m
+	for newR != 0 {
+		q := r / newR
+		// t - q*newT, kept in [0, m) without going through values above m
+		x := mulMod(q%m, newT, m)
+		if t >= x {
+			t, newT = newT, t-x
+		} else {
+			t, newT = newT, t+(m-x)
+		}
+		r, newR = newR, r-q*newR
+	}
+	return t, true
+}
+
+// mulMod returns a*b mod m without overflowing, a and b must be below m
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, rem := bits.Div64(hi, lo, m)
+	return rem
+}
+
+// IntegerMultiplier returns the smallest positive k such that k*f is an integer, which is always its denominator
+func (f Fraction) IntegerMultiplier() uint64 {
+	return f.denominator
+}
+
+// IntegerMultiplierOf returns the smallest positive k such that k times each of the fractions is an integer, that
+// is, the least common multiple of their denominators. An empty list returns 1.
+//
+// It returns ErrOutOfRange if the least common multiple doesn't fit in an uint64
+func IntegerMultiplierOf(fs ...Fraction) (uint64, error) {
+	k := uint64(1)
+	for _, f := range fs {
+		var ok bool
+		if k, ok = lcm(k, f.denominator); !ok {
+			return 0, ErrOutOfRange
+		}
+	}
+	return k, nil
+}
+
+// CommonGrid returns the coarsest grid both a and b sit on, the unit fraction 1/d where d is the least common
+// multiple of their denominators (which is what IntegerMultiplierOf(a, b) returns), so a tick scale in thirds and
+// one in quarters share the grid CommonGrid(1/3, 1/4) = 1/12. Both a and b are exact multiples of the result.
+//
+// It returns ErrOutOfRange if the least common multiple doesn't fit in an uint64
+func CommonGrid(a, b Fraction) (Fraction, error) {
+	d, err := IntegerMultiplierOf(a, b)
+	if err != nil {
+		return zeroValue, err
+	}
+	return Fraction{numerator: 1, denominator: d}, nil
+}
+
+// lcm returns the least common multiple of two positive numbers, the bool is false if it overflows
+func lcm(n1, n2 uint64) (uint64, bool) {
+	scale := n2 / gcd(n1, n2)
+	if n1 > math.MaxUint64/scale {
+		return 0, false
+	}
+	return n1 * scale, true
+}
+
+// primesUpTo returns every prime <= n in ascending order using the sieve of Eratosthenes. It allocates n+1 bytes,
+// so callers have to bound n
+func primesUpTo(n uint64) []uint64 {
+	if n < 2 {
+		return nil
+	}
+
+	composite := make([]bool, n+1)
+	var primes []uint64
+	for i := uint64(2); i <= n; i++ {
+		if composite[i] {
+			continue
+		}
+		primes = append(primes, i)
+		for j := i * i; j <= n && j >= i; j += i {
+			composite[j] = true
+		}
+	}
+	return primes
+}
+
+// factorize returns the prime factors of n along with their exponents, in ascending order.
+// Small factors go away by trial division and the rest are split with Pollard's rho, which takes around n^(1/4)
+// steps per factor, so even a 64-bit semiprime is factored in about 2^16 steps
+func factorize(n uint64) (primes []uint64, exps []int) {
+	var factors []uint64
+	for p := uint64(2); p < 64; p++ {
+		for n%p == 0 {
+			n /= p
+			factors = append(factors, p)
+		}
+	}
+	factors = splitFactors(n, factors)
+	slices.Sort(factors)
+
+	for _, p := range factors {
+		if len(primes) > 0 && primes[len(primes)-1] == p {
+			exps[len(exps)-1]++
+			continue
+		}
+		primes = append(primes, p)
+		exps = append(exps, 1)
+	}
+	return primes, exps
+}
+
+// splitFactors appends the prime factors of n to factors, with repetition and in no particular order. n must have
+// no factor below 64
+func splitFactors(n uint64, factors []uint64) []uint64 {
+	if n == 1 {
+		return factors
+	}
+	if isPrime(n) {
+		return append(factors, n)
+	}
+	d := pollardRho(n)
+	factors = splitFactors(d, factors)
+	return splitFactors(n/d, factors)
+}
+
+// pollardRho returns a non trivial divisor of the odd composite n, trying x^2 + c for c = 1, 2, ... until the
+// sequence finds one
+func pollardRho(n uint64) uint64 {
+	for c := uint64(1); ; c++ {
+		next := func(x uint64) uint64 {
+			sum, carry := bits.Add64(mulMod(x, x, n), c, 0)
+			if carry != 0 || sum >= n {
+				sum -= n
+			}
+			return sum
+		}
+
+		x, y, d := uint64(2), uint64(2), uint64(1)
+		for d == 1 {
+			x = next(x)
+			y = next(next(y))
+			if x > y {
+				d = gcd(x-y, n)
+			} else {
+				d = gcd(y-x, n)
+			}
+		}
+		if d != n {
+			return d
+		}
+	}
+}
+
+// isPrime reports whether n is prime with a Miller-Rabin test, the first 12 primes as bases make it exact for
+// every uint64
+func isPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	bases := []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+	for _, p := range bases {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+
+	// n - 1 = d * 2^s with d odd
+	s := bits.TrailingZeros64(n - 1)
+	d := (n - 1) >> s
+	for _, a := range bases {
+		x := powMod(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		composite := true
+		for range s - 1 {
+			x = mulMod(x, x, n)
+			if x == n-1 {
+				composite = false
+				break
+			}
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+// powMod returns a^e mod m by squaring, a must be below m
+func powMod(a, e, m uint64) uint64 {
+	res := uint64(1)
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			res = mulMod(res, a, m)
+		}
+		a = mulMod(a, a, m)
+	}
+	return res
+}
+
+// squareDivisors returns the divisors of n^2 that are <= n, sorted in ascending order
+func squareDivisors(n uint64) []uint64 {
+	primes, exps := factorize(n)
+
+	divs := []uint64{1}
+	for i, p := range primes {
+		current := len(divs)
+		for j := range current {
+			v := divs[j]
+			for range 2 * exps[i] {
+				if v > n/p {
+					break
+				}
+				v *= p
+				divs = append(divs, v)
+			}
+		}
+	}
+
+	slices.Sort(divs)
+	return divs
+}
@@ -0,0 +1,61 @@
+package fraction
+
+import "slices"
+
+// OrderedFractionMap maps fraction values to V and iterates them in ascending key order, the zero value is ready
+// to use. Keys are compared by value with Cmp, so 2/4 and 1/2 are the same key.
+//
+// It's backed by a slice kept sorted by key, lookups are binary searches and inserts or deletes shift the entries
+// after the key, which is cheap for the small to medium maps it's meant for
+type OrderedFractionMap[V any] struct {
+	keys   []Fraction
+	values []V
+}
+
+// find returns where key is or would be inserted, and whether it's there
+func (m *OrderedFractionMap[V]) find(key Fraction) (int, bool) {
+	return slices.BinarySearchFunc(m.keys, key, Cmp)
+}
+
+// Set stores value under key, replacing the value already stored for an equal key
+func (m *OrderedFractionMap[V]) Set(key Fraction, value V) {
+	i, found := m.find(key)
+	if found {
+		m.values[i] = value
+		return
+	}
+	m.keys = slices.Insert(m.keys, i, key)
+	m.values = slices.Insert(m.values, i, value)
+}
+
+// Get returns the value stored under key, the bool is false (and the value is the zero V) if there's none
+func (m *OrderedFractionMap[V]) Get(key Fraction) (V, bool) {
+	if i, found := m.find(key); found {
+		return m.values[i], true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes key from the map, deleting a key that isn't there does nothing
+func (m *OrderedFractionMap[V]) Delete(key Fraction) {
+	if i, found := m.find(key); found {
+		m.keys = slices.Delete(m.keys, i, i+1)
+		m.values = slices.Delete(m.values, i, i+1)
+	}
+}
+
+// Len returns the number of keys in the map
+func (m *OrderedFractionMap[V]) Len() int {
+	return len(m.keys)
+}
+
+// Range calls fn for every key and value in ascending key order, stopping as soon as fn returns false.
+// The map shouldn't be modified from fn
+func (m *OrderedFractionMap[V]) Range(fn func(key Fraction, value V) bool) {
+	for i, k := range m.keys {
+		if !fn(k, m.values[i]) {
+			return
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package fraction
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ParseDecimalRaw parses the string of a decimal number into its unreduced components
+//
+// Unlike ParseDecimal, the result isn't simplified, the denominator is always 10^(digits after the dot), so
+// "0.20" returns 20, 100 and "-1.5" returns 15, 10 (negative). Use New() on the components if you want them reduced.
+// Can return ErrOutOfRange if either component doesn't fit in an uint64
+func ParseDecimalRaw(s string) (numerator, denominator uint64, negative bool, err error) {
+	str, negative := cutSign(s)
+	if str == "" {
+		return 0, 0, false, errors.New("empty decimal")
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) > 2 {
+		return 0, 0, false, errors.New("too much dots")
+	}
+	if parts[0] == "" {
+		return 0, 0, false, errors.New("no leading numeral at left hand side of decimal")
+	}
+
+	denominator = 1
+	digits := parts[0]
+	if len(parts) == 2 {
+		if parts[1] == "" {
+			return 0, 0, false, errors.New("no numerals at right hand side of decimal")
+		}
+		for range len(parts[1]) {
+			if denominator > math.MaxUint64/10 {
+				return 0, 0, false, ErrOutOfRange
+			}
+			denominator *= 10
+		}
+		digits += parts[1]
+	}
+
+	numerator, err = strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, 0, false, ErrOutOfRange
+		}
+		return 0, 0, false, err
+	}
+
+	// Zero is never negative, same as in the Fraction invariant
+	if numerator == 0 {
+		negative = false
+	}
+
+	return numerator, denominator, negative, nil
+}
+
+// FromDecimalStrings creates a fraction from a numerator and a denominator written as decimal integer strings
+//
+// Both strings can be bigger than what an uint64 can hold, the value is reduced first and ErrOutOfRange is only
+// returned if the reduced fraction still doesn't fit, so "1000000000000000000000" over "2000000000000000000000"
+// returns 1/2. Returns ErrInvalid if either string isn't an integer and ErrZeroDenominator if the denominator is 0
+func FromDecimalStrings(numStr, denStr string) (Fraction, error) {
+	num, ok := new(big.Int).SetString(strings.TrimSpace(numStr), 10)
+	if !ok {
+		return zeroValue, ErrInvalid
+	}
+	den, ok := new(big.Int).SetString(strings.TrimSpace(denStr), 10)
+	if !ok {
+		return zeroValue, ErrInvalid
+	}
+	if den.Sign() == 0 {
+		return zeroValue, ErrZeroDenominator
+	}
+
+	return fromRat(new(big.Rat).SetFrac(num, den))
+}
+
+// ParseEuropean parses a decimal number written in the usual European way, with a comma as the decimal point and
+// the integer part optionally grouped by thousands, so "1 234,56" returns 30864/25 and "-0,5" returns -1/2.
+//
+// Groups can be separated by spaces (including the non-breaking ones) or by dots, "1.234,56" is also accepted, but
+// only one kind of separator per number and only in properly sized groups: the first one has 1 to 3 digits and
+// every other one exactly 3. Anything else, like "1,234.56", "12 34,5" or a dot after the comma, returns
+// ErrInvalid, and ErrOutOfRange is returned if the number doesn't fit
+func ParseEuropean(s string) (Fraction, error) {
+	str, negative := cutSign(s)
+
+	intPart, fracPart, hasComma := strings.Cut(str, ",")
+	digits, ok := ungroupThousands(intPart)
+	if !ok {
+		return zeroValue, ErrInvalid
+	}
+	if hasComma {
+		if fracPart == "" || scanDigits(fracPart, 0) != len(fracPart) {
+			return zeroValue, ErrInvalid
+		}
+		digits += "." + fracPart
+	}
+
+	num, den, _, err := ParseDecimalRaw(digits)
+	if err != nil {
+		if errors.Is(err, ErrOutOfRange) {
+			return zeroValue, err
+		}
+		return zeroValue, ErrInvalid
+	}
+	return Fraction{numerator: num, denominator: den, negative: negative}.normalize(), nil
+}
+
+// ungroupThousands removes the thousands separators (spaces, non-breaking spaces or dots) from an integer,
+// checking that every group is properly sized. The bool is false if s isn't a well grouped integer
+func ungroupThousands(s string) (string, bool) {
+	s = strings.NewReplacer("\u00a0", " ", "\u202f", " ").Replace(s)
+	if strings.Contains(s, " ") && strings.Contains(s, ".") {
+		return "", false
+	}
+
+	sep := " "
+	if strings.Contains(s, ".") {
+		sep = "."
+	}
+	groups := strings.Split(s, sep)
+	for i, g := range groups {
+		if g == "" || scanDigits(g, 0) != len(g) {
+			return "", false
+		}
+		if len(groups) > 1 && (i == 0 && len(g) > 3 || i > 0 && len(g) != 3) {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+// ParsePrefix parses the fraction at the start of s and returns the rest of the string that wasn't consumed
+//
+// Leading whitespace and an optional sign are accepted, taken the same way as the other parsers do ("-3/4",
+// "- 3/4" and "−3/4" are all negative), followed by the longest of these forms:
+//   - A mixed number, "1 1/2" (whole part, whitespace and a proper fraction)
+//   - A fraction, "1/2" (no spaces around the '/')
+//   - A decimal, "1.25"
+//   - An integer, "12"
+//
+// The number ends at the first character that doesn't continue one of these forms, so "1/2x" returns 1/2 and "x",
+// "1 1/2 cups" returns 3/2 and " cups", and "3/ 4" returns 3 and "/ 4". It returns ErrInvalid if s doesn't start
+// with a number, ErrZeroDenominator for a zero denominator and ErrOutOfRange if the number doesn't fit
+func ParsePrefix(s string) (f Fraction, rest string, err error) {
+	unsigned, negative := cutLeadingSign(s)
+	i := len(s) - len(unsigned)
+
+	numEnd := scanDigits(s, i)
+	if numEnd == i {
+		return zeroValue, s, ErrInvalid
+	}
+	num, err := parseDigits(s[i:numEnd])
+	if err != nil {
+		return zeroValue, s, err
+	}
+
+	end := numEnd
+	switch {
+	case isFracSeparator(s, numEnd, '/'):
+		// Fraction: "n/d"
+		end = scanDigits(s, numEnd+1)
+		den, err := parseDigits(s[numEnd+1 : end])
+		if err != nil {
+			return zeroValue, s, err
+		}
+		if den == 0 {
+			return zeroValue, s, ErrZeroDenominator
+		}
+		f = Fraction{numerator: num, denominator: den}.normalize()
+
+	case isFracSeparator(s, numEnd, '.'):
+		// Decimal: "n.m"
+		end = scanDigits(s, numEnd+1)
+		n, d, _, err := ParseDecimalRaw(s[i:end])
+		if err != nil {
+			return zeroValue, s, err
+		}
+		f = Fraction{numerator: n, denominator: d}.normalize()
+
+	default:
+		// Integer, which may be the whole part of a mixed number "w n/d"
+		f = Fraction{numerator: num, denominator: 1}
+
+		j := skipBlanks(s, numEnd)
+		k := scanDigits(s, j)
+		if j == numEnd || k == j || !isFracSeparator(s, k, '/') {
+			break
+		}
+		m := scanDigits(s, k+1)
+		n, err1 := parseDigits(s[j:k])
+		d, err2 := parseDigits(s[k+1 : m])
+		// Only proper fractions make a mixed number, anything else is left in the rest
+		if err1 != nil || err2 != nil || d == 0 || n >= d {
+			break
+		}
+		if f, err = Add(f, Fraction{numerator: n, denominator: d}.normalize()); err != nil {
+			return zeroValue, s, err
+		}
+		end = m
+	}
+
+	if negative {
+		f = f.Negate()
+	}
+	return f, s[end:], nil
+}
+
+// skipBlanks returns the index of the first character from i onwards that isn't a space or a tab
+func skipBlanks(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+// scanDigits returns the index of the first character from i onwards that isn't an ASCII digit
+func scanDigits(s string, i int) int {
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// isFracSeparator reports whether s has the separator sep at i, immediately followed by a digit
+func isFracSeparator(s string, i int, sep byte) bool {
+	return i+1 < len(s) && s[i] == sep && s[i+1] >= '0' && s[i+1] <= '9'
+}
+
+// parseDigits parses a string of ASCII digits, returning ErrOutOfRange if it doesn't fit in an uint64
+func parseDigits(digits string) (uint64, error) {
+	v, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, ErrOutOfRange
+		}
+		return 0, ErrInvalid
+	}
+	return v, nil
+}
@@ -0,0 +1,123 @@
+package fraction
+
+import "strconv"
+
+// PercentDifference returns the relative change from a to b, (b - a) / a, as a fraction.
+// PercentDifference(4, 5) returns 1/4, meaning b is 25% more than a.
+//
+// It returns ErrDivideByZero if a is zero and can return ErrOutOfRange if any step overflows
+func PercentDifference(a, b Fraction) (Fraction, error) {
+	if a.isZero() {
+		return zeroValue, ErrDivideByZero
+	}
+	return Start(b).Sub(a).Div(a).Result()
+}
+
+// PercentChangeString formats the relative change from a to b as a signed percentage rounded to two decimal
+// places, so going from 4 to 5 returns "+25%", from 3 to 2 returns "-33.33%" and no change returns "0%"
+//
+// It returns the same errors as PercentDifference
+func PercentChangeString(a, b Fraction) (string, error) {
+	diff, err := PercentDifference(a, b)
+	if err != nil {
+		return "", err
+	}
+	pct, err := Multiply(diff, NewI(100))
+	if err != nil {
+		return "", err
+	}
+
+	str := pct.roundedDecimalString(2)
+	if str != "0" && !pct.negative {
+		str = "+" + str
+	}
+	return str + "%", nil
+}
+
+// PartsPer returns round(f * base) with halves rounded away from zero and whether that's exact, so
+// (3/1000000).PartsPer(1000000) returns 3, true (3 ppm) and (1/3).PartsPer(100) returns 33, false.
+// A base of 0 or a result that doesn't fit in an int64 return 0, false
+func (f Fraction) PartsPer(base uint64) (int64, bool) {
+	if base == 0 {
+		return 0, false
+	}
+	n, ok := scaledRound(f, base)
+	if !ok {
+		return 0, false
+	}
+	return n, base%f.denominator == 0
+}
+
+// PartsPerString formats f * base rounded to two decimal places, followed by the usual name of the base: "%" for
+// 100, "ppm" for a million, "ppb" for a billion and "ppt" for a trillion. Any other base is written out, so
+// (3/1000000).PartsPerString(1000000) returns "3 ppm" and (1/8).PartsPerString(1000) returns "125 per 1000".
+//
+// It returns ErrInvalid if base is 0 and can return ErrOutOfRange if f * base overflows
+func (f Fraction) PartsPerString(base uint64) (string, error) {
+	if base == 0 {
+		return "", ErrInvalid
+	}
+	scaled, err := Multiply(f, NewI(base))
+	if err != nil {
+		return "", err
+	}
+
+	str := scaled.roundedDecimalString(2)
+	switch base {
+	case 100:
+		return str + "%", nil
+	case 1_000_000:
+		return str + " ppm", nil
+	case 1_000_000_000:
+		return str + " ppb", nil
+	case 1_000_000_000_000:
+		return str + " ppt", nil
+	}
+	return str + " per " + strconv.FormatUint(base, 10), nil
+}
+
+// PercentOf returns how much of total f is as an integer percentage, round(100 * f / total) with the given rounding
+// mode, clamped to [0, 100] so it can go straight into a progress bar. The math is exact, so 2/3 of 2/3 is always
+// 100 and never 99. With RoundDown, anything short of the total stays below 100.
+//
+// It returns ErrDivideByZero if total is zero, ErrInvalid for an unknown rounding mode and can return
+// ErrOutOfRange if any of the steps overflow
+func (f Fraction) PercentOf(total Fraction, round RoundingMode) (int, error) {
+	if total.isZero() {
+		return 0, ErrDivideByZero
+	}
+	if round != RoundDown && round != RoundUp && round != RoundNearest {
+		return 0, ErrInvalid
+	}
+
+	pct, err := Start(f).Div(total).Mult(NewI(100)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if pct.negative || pct.isZero() {
+		return 0, nil
+	}
+	if pct.GreaterEq(NewI(100)) {
+		return 100, nil
+	}
+
+	// Between 0 and 100, so it always fits
+	n, _ := roundInt(pct, round)
+	return int(n), nil
+}
+
+// RatioOf returns the ratio a:b in lowest whole terms, which are the numerator and denominator of a/b, so the
+// ratio of 1/2 to 1/3 is 3:2 and the ratio of 4 to 6 is 2:3. negative is true when a and b have opposite signs,
+// and a zero a gives 0:1.
+//
+// It returns ErrDivideByZero if b is zero and can return ErrOutOfRange if the division overflows
+func RatioOf(a, b Fraction) (numer uint64, denom uint64, negative bool, err error) {
+	if b.isZero() {
+		return 0, 0, false, ErrDivideByZero
+	}
+	q, err := Divide(a, b)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return q.numerator, q.denominator, q.negative, nil
+}
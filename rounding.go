@@ -0,0 +1,408 @@
+package fraction
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"slices"
+)
+
+// RoundingMode selects which way a value is rounded to an integer
+type RoundingMode int
+
+const (
+	// RoundDown rounds toward negative infinity (floor)
+	RoundDown RoundingMode = iota
+	// RoundUp rounds toward positive infinity (ceil)
+	RoundUp
+	// RoundNearest rounds to the nearest integer, with halves rounded away from zero
+	RoundNearest
+)
+
+// scaledFloor returns floor(f * scale) as an int64 along with whether f * scale was already an integer.
+// ok is false if the result doesn't fit in an int64
+func scaledFloor(f Fraction, scale uint64) (floor int64, exact bool, ok bool) {
+	hi, lo := bits.Mul64(f.numerator, scale)
+	if hi >= f.denominator {
+		return 0, false, false
+	}
+	q, r := bits.Div64(hi, lo, f.denominator)
+	exact = r == 0
+
+	if !f.negative {
+		if q > math.MaxInt64 {
+			return 0, false, false
+		}
+		return int64(q), exact, true
+	}
+
+	// floor(-x) = -ceil(x)
+	if q > 1<<63 || !exact && q == 1<<63 {
+		return 0, false, false
+	}
+	if !exact {
+		q++
+	}
+	return int64(-q), exact, true
+}
+
+// maxFractionsCount bounds how many fractions the functions listing them (FractionsWithDenominator,
+// RationalLattice, UnitParts, SemiConvergents) return or walk
+const maxFractionsCount = 1 << 20
+
+// FractionsWithDenominator returns every value k/d strictly between a and b, in ascending order and reduced, so
+// the fractions with denominator 4 between 0 and 1 are [1/4, 1/2, 3/4].
+//
+// It returns ErrZeroDenominator if d is 0, ErrInvalid if a >= b and ErrOutOfRange if the numerators don't fit in
+// an int64 or there would be more than 2^20 fractions
+func FractionsWithDenominator(a, b Fraction, d uint64) ([]Fraction, error) {
+	if d == 0 {
+		return nil, ErrZeroDenominator
+	}
+	if Cmp(a, b) >= 0 {
+		return nil, ErrInvalid
+	}
+
+	// k goes from floor(a*d) + 1 to ceil(b*d) - 1
+	lo, _, ok := scaledFloor(a, d)
+	if !ok {
+		return nil, ErrOutOfRange
+	}
+	hi, exact, ok := scaledFloor(b, d)
+	if !ok {
+		return nil, ErrOutOfRange
+	}
+	if exact {
+		hi--
+	}
+	if hi <= lo {
+		return nil, nil
+	}
+	count := uint64(hi) - uint64(lo)
+	if count > maxFractionsCount {
+		return nil, ErrOutOfRange
+	}
+
+	res := make([]Fraction, 0, count)
+	for i := range int64(count) {
+		k := lo + 1 + i
+		res = append(res, Fraction{numerator: uint64(abs(k)), denominator: d, negative: k < 0}.normalize())
+	}
+	return res, nil
+}
+
+// RationalLattice returns every reduced fraction in [lo, hi] whose denominator is at most maxDen, in ascending
+// order and without duplicates, the Farey sequence generalized to any interval. [1, 2] with a maxDen of 3 gives
+// [1, 4/3, 3/2, 5/3, 2]. The amount of values grows with (hi-lo)*maxDen^2, so keep both of them reasonable.
+//
+// It returns ErrZeroDenominator if maxDen is 0, ErrInvalid if lo > hi and ErrOutOfRange if maxDen is above 2^20,
+// the numerators don't fit in an int64 or there would be more than 2^20 fractions
+func RationalLattice(lo, hi Fraction, maxDen uint64) ([]Fraction, error) {
+	if maxDen == 0 {
+		return nil, ErrZeroDenominator
+	}
+	// Every denominator up to maxDen is visited, even for an empty interval
+	if maxDen > maxFractionsCount {
+		return nil, ErrOutOfRange
+	}
+	if Cmp(lo, hi) > 0 {
+		return nil, ErrInvalid
+	}
+
+	// The numerators are the biggest at maxDen, if they fit there they fit for every denominator
+	if _, _, ok := scaledFloor(lo, maxDen); !ok {
+		return nil, ErrOutOfRange
+	}
+	if _, _, ok := scaledFloor(hi, maxDen); !ok {
+		return nil, ErrOutOfRange
+	}
+
+	res := []Fraction{}
+	for q := uint64(1); q <= maxDen; q++ {
+		// k goes from ceil(lo*q) to floor(hi*q)
+		first, exact, _ := scaledFloor(lo, q)
+		last, _, _ := scaledFloor(hi, q)
+		if !exact {
+			if first == last {
+				continue
+			}
+			first++
+		}
+
+		// Only the reduced k/q are kept, the others already showed up with a smaller denominator
+		for k := first; ; k++ {
+			if gcd(uint64(abs(k)), q) == 1 {
+				if len(res) == maxFractionsCount {
+					return nil, ErrOutOfRange
+				}
+				res = append(res, Fraction{numerator: uint64(abs(k)), denominator: q, negative: k < 0}.normalize())
+			}
+			if k == last {
+				break
+			}
+		}
+	}
+
+	slices.SortFunc(res, Cmp)
+	return res, nil
+}
+
+// UnitParts returns the gridlines that split one whole into f's denominator equal parts, that is, the d+1 values
+// 0, 1/d, 2/d, ..., 1 where d is the reduced denominator. They're reduced as well, so 3/4 returns
+// [0, 1/4, 1/2, 3/4, 1] and integers return [0, 1]. The sign of f is ignored.
+//
+// It returns ErrOutOfRange if there would be more than 2^20 gridlines
+func (f Fraction) UnitParts() ([]Fraction, error) {
+	d := f.denominator
+	if d >= maxFractionsCount {
+		return nil, ErrOutOfRange
+	}
+	parts := make([]Fraction, 0, d+1)
+	for k := range d + 1 {
+		parts = append(parts, Fraction{numerator: k, denominator: d}.normalize())
+	}
+	return parts, nil
+}
+
+// roundInt rounds f to an int64 following the given mode. ok is false if the mode is unknown or the result doesn't
+// fit in an int64
+func roundInt(f Fraction, mode RoundingMode) (n int64, ok bool) {
+	return roundScaled(f, 1, mode)
+}
+
+// roundScaled rounds f * scale to an int64 following the given mode. ok is false if the mode is unknown or the
+// result doesn't fit in an int64
+func roundScaled(f Fraction, scale uint64, mode RoundingMode) (n int64, ok bool) {
+	switch mode {
+	case RoundDown, RoundUp:
+		floor, exact, ok := scaledFloor(f, scale)
+		if !ok {
+			return 0, false
+		}
+		if mode == RoundUp && !exact {
+			if floor == math.MaxInt64 {
+				return 0, false
+			}
+			floor++
+		}
+		return floor, true
+	case RoundNearest:
+		return scaledRound(f, scale)
+	}
+	return 0, false
+}
+
+// scaledRound returns round(f * scale) as an int64, with halves rounded away from zero.
+// ok is false if the result doesn't fit in an int64
+func scaledRound(f Fraction, scale uint64) (int64, bool) {
+	q, ok := roundedMagnitude(f, scale)
+	if !ok {
+		return 0, false
+	}
+
+	if f.negative {
+		if q > 1<<63 {
+			return 0, false
+		}
+		return int64(-q), true
+	}
+	if q > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(q), true
+}
+
+// roundedMagnitude returns round(|f| * scale), with halves rounded up. ok is false if it doesn't fit in an uint64
+func roundedMagnitude(f Fraction, scale uint64) (uint64, bool) {
+	hi, lo := bits.Mul64(f.numerator, scale)
+	if hi >= f.denominator {
+		return 0, false
+	}
+	q, r := bits.Div64(hi, lo, f.denominator)
+	if r >= f.denominator-r {
+		if q == math.MaxUint64 {
+			return 0, false
+		}
+		q++
+	}
+	return q, true
+}
+
+// QuantizeDecimal rounds f to the given number of decimal places, returning round(f * 10^places) / 10^places
+// with halves rounded away from zero, so (1/3).QuantizeDecimal(2) returns 33/100.
+//
+// It returns ErrInvalid if places is negative and ErrOutOfRange if 10^places or the scaled value overflow
+func (f Fraction) QuantizeDecimal(places int) (Fraction, error) {
+	if places < 0 {
+		return zeroValue, ErrInvalid
+	}
+	if places > 19 {
+		return zeroValue, ErrOutOfRange
+	}
+
+	scale := pow10(places)
+	q, ok := roundedMagnitude(f, scale)
+	if !ok {
+		return zeroValue, ErrOutOfRange
+	}
+	return Fraction{numerator: q, denominator: scale, negative: f.negative}.normalize(), nil
+}
+
+// DenominatorAllowed reports whether the reduced denominator of f is one of the allowed ones. With quarters and
+// halves allowed ([]uint64{2, 4}), 3/4 and 1/2 pass while 1/3 and 1/8 don't, and integers only pass if 1 is
+// allowed. Use OnDenominatorGrid to also accept the values that reduce from an allowed denominator, like 1/2 (2/4)
+// with quarters
+func (f Fraction) DenominatorAllowed(allowed []uint64) bool {
+	return slices.Contains(allowed, f.denominator)
+}
+
+// OnDenominatorGrid reports whether f can be written as k/d for one of the allowed denominators d, that is,
+// whether its reduced denominator divides one of them. With quarters allowed, 3/4 and 1/2 (2/4) pass while 1/3
+// doesn't, and with cents ([]uint64{100}) every value of the form 0.xx passes. Zeros in allowed are ignored
+func (f Fraction) OnDenominatorGrid(allowed []uint64) bool {
+	for _, d := range allowed {
+		if d != 0 && d%f.denominator == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// CoerceToAllowed snaps f to the grid of one of the allowed denominators, returning the closest value of the form
+// k/d for an allowed d in the direction given by round: RoundDown gives the largest one that isn't above f, RoundUp
+// the smallest one that isn't below f and RoundNearest the closest one, with halves rounded away from zero and
+// ties between grids going to the smaller denominator. With quarters allowed, 1/3 goes to 1/4 rounding down or
+// to the nearest and to 1/2 rounding up. Values already on one of the grids (see OnDenominatorGrid) come back as
+// they are, and like everything else the result is reduced, so 2/4 comes back as 1/2.
+//
+// It returns ErrInvalid if allowed is empty or round isn't a known mode, ErrZeroDenominator if allowed contains
+// 0 and ErrOutOfRange if k doesn't fit in an int64
+func (f Fraction) CoerceToAllowed(allowed []uint64, round RoundingMode) (Fraction, error) {
+	if len(allowed) == 0 || round < RoundDown || round > RoundNearest {
+		return zeroValue, ErrInvalid
+	}
+	if slices.Contains(allowed, 0) {
+		return zeroValue, ErrZeroDenominator
+	}
+	if f.OnDenominatorGrid(allowed) {
+		return f, nil
+	}
+
+	var best Fraction
+	for i, d := range allowed {
+		k, ok := roundScaled(f, d, round)
+		if !ok {
+			return zeroValue, ErrOutOfRange
+		}
+		cand := Fraction{numerator: uint64(abs(k)), denominator: d, negative: k < 0}.normalize()
+
+		switch {
+		case i == 0,
+			round == RoundDown && cand.Greater(best),
+			round == RoundUp && cand.Less(best):
+			best = cand
+		case round == RoundNearest:
+			best = closest(f, best, cand)
+		}
+	}
+	return best, nil
+}
+
+// Bracket returns the two integers surrounding f, the biggest integer <= f and the smallest integer >= f.
+// -7/3 returns -3 and -2, integers return themselves twice
+func (f Fraction) Bracket() (floor Fraction, ceil Fraction) {
+	if f.denominator == 1 {
+		return f, f
+	}
+
+	// Non integers have a denominator of at least 2, so q+1 can't overflow
+	q := f.numerator / f.denominator
+	lower := Fraction{numerator: q, denominator: 1, negative: f.negative}.normalize()
+	upper := Fraction{numerator: q + 1, denominator: 1, negative: f.negative}
+	if f.negative {
+		return upper, lower
+	}
+	return lower, upper
+}
+
+// DistanceToNearestInteger returns how far f is from the closest integer, a value in [0, 1/2] that's 0 for
+// integers. It only takes the remainder of the division, so (7/3) returns 1/3, (5/2) returns 1/2 and (-7/3) 1/3
+func (f Fraction) DistanceToNearestInteger() Fraction {
+	r := f.numerator % f.denominator
+	return Fraction{numerator: min(r, f.denominator-r), denominator: f.denominator}.normalize()
+}
+
+// QuantizeSymmetric rounds f to the nearest multiple of step, with halves rounded away from zero. The rounding is
+// done on |f| and the sign put back afterwards, so x and -x always snap to opposite values: with a step of 1/4,
+// 3/8 goes to 1/2 and -3/8 goes to -1/2.
+//
+// It returns ErrInvalid if step isn't positive and ErrOutOfRange if any of the steps overflow
+func (f Fraction) QuantizeSymmetric(step Fraction) (Fraction, error) {
+	if step.negative || step.isZero() {
+		return zeroValue, ErrInvalid
+	}
+
+	ratio, err := Divide(f.Abs(), step)
+	if err != nil {
+		return zeroValue, err
+	}
+	k, ok := roundedMagnitude(ratio, 1)
+	if !ok {
+		return zeroValue, ErrOutOfRange
+	}
+	res, err := Multiply(NewI(k), step)
+	if err != nil {
+		return zeroValue, err
+	}
+	if f.negative {
+		res = res.Negate()
+	}
+	return res, nil
+}
+
+// CountFits returns how many whole units fit in f and what's left over, so 2/3 of a cup holds 2 quarter cups with
+// 1/6 of a cup to spare. count is floor(f / unit) and remainder is f - count*unit, which means that with negative
+// values the remainder always has the sign of unit (or is zero), like a floored modulo.
+//
+// It returns ErrDivideByZero if unit is zero and ErrOutOfRange if the count doesn't fit in an int64 or any of the
+// steps overflow
+func (f Fraction) CountFits(unit Fraction) (count int64, remainder Fraction, err error) {
+	if unit.isZero() {
+		return 0, zeroValue, ErrDivideByZero
+	}
+
+	q, err := Divide(f, unit)
+	if err != nil {
+		return 0, zeroValue, err
+	}
+	count, _, ok := scaledFloor(q, 1)
+	if !ok {
+		return 0, zeroValue, ErrOutOfRange
+	}
+	remainder, err = Start(NewI(count)).Mult(unit).Negate().Sum(f).Result()
+	if err != nil {
+		return 0, zeroValue, err
+	}
+	return count, remainder, nil
+}
+
+// WrapInto reduces f into the half-open interval [0, m) by adding or removing whole multiples of m, the exact
+// version of wrapping an angle or a phase around, so 7/2 wrapped into 3 is 1/2 and -1/2 is 5/2. Unlike the
+// remainder of CountFits, the number of turns is never materialized, so it works however far f is from the range.
+//
+// It returns ErrDivideByZero if m is zero, ErrInvalid if m is negative and ErrOutOfRange if the result doesn't fit
+func (f Fraction) WrapInto(m Fraction) (Fraction, error) {
+	if m.isZero() {
+		return zeroValue, ErrDivideByZero
+	}
+	if m.negative {
+		return zeroValue, ErrInvalid
+	}
+
+	// f - m*floor(f/m), big.Int.Div rounds toward negative infinity for positive divisors
+	a, b := f.rat(), m.rat()
+	q := new(big.Rat).Quo(a, b)
+	turns := new(big.Int).Div(q.Num(), q.Denom())
+	a.Sub(a, b.Mul(b, new(big.Rat).SetInt(turns)))
+	return fromRat(a)
+}
@@ -0,0 +1,65 @@
+package fraction
+
+import "math"
+
+// DecimalScanner parses a decimal number fed one byte at a time, without buffering the string, so it can sit on top
+// of a byte stream. It implements io.ByteWriter and the zero value is ready to use.
+//
+// It accepts an optional leading '-' or '+', digits and at most one '.' with digits on both sides, and nothing else.
+// That's stricter than ParseDecimalRaw, which also takes surrounding whitespace and the Unicode minus sign but no
+// '+'. The numerator and the power of ten denominator are kept up to date on every byte
+type DecimalScanner struct {
+	numerator   uint64
+	denominator uint64
+	negative    bool
+	started     bool // a sign or a digit was written
+	digits      bool // a digit was written since the start or since the dot
+	dot         bool
+}
+
+// WriteByte feeds the next byte of the number. A byte that doesn't fit is rejected with ErrInvalid and leaves the
+// scanner untouched, so "-12.34" can be written byte by byte but a second '.' or a sign after the first digit can't.
+// It returns ErrOutOfRange if the numerator or the denominator stop fitting in an uint64
+func (s *DecimalScanner) WriteByte(b byte) error {
+	switch {
+	case b == '-' || b == '+':
+		if s.started {
+			return ErrInvalid
+		}
+		s.negative, s.started = b == '-', true
+
+	case b == '.':
+		if s.dot || !s.digits {
+			return ErrInvalid
+		}
+		s.dot, s.digits = true, false
+
+	case b >= '0' && b <= '9':
+		den := max(s.denominator, 1)
+		if s.dot {
+			if den > math.MaxUint64/10 {
+				return ErrOutOfRange
+			}
+			den *= 10
+		}
+		d := uint64(b - '0')
+		if s.numerator > (math.MaxUint64-d)/10 {
+			return ErrOutOfRange
+		}
+		s.numerator, s.denominator = s.numerator*10+d, den
+		s.started, s.digits = true, true
+
+	default:
+		return ErrInvalid
+	}
+	return nil
+}
+
+// Fraction returns the number written so far, reduced. It returns ErrInvalid if it isn't a complete number yet,
+// like when nothing was written, only a sign, or a '.' without digits after it
+func (s *DecimalScanner) Fraction() (Fraction, error) {
+	if !s.digits {
+		return zeroValue, ErrInvalid
+	}
+	return Fraction{numerator: s.numerator, denominator: max(s.denominator, 1), negative: s.negative}.normalize(), nil
+}
@@ -0,0 +1,99 @@
+package fraction_test
+
+import (
+	"errors"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- ProductAccumulator ----------------------------------------------------
+
+func TestProductAccumulator_ZeroValueIsOne(t *testing.T) {
+	var p frac.ProductAccumulator
+	got, err := p.Fraction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(frac.One()) {
+		t.Fatalf("empty product = %v, want 1", got)
+	}
+}
+
+func TestProductAccumulator_Basic(t *testing.T) {
+	var p frac.ProductAccumulator
+	p.Multiply(mustNew(t, 1, 2))
+	p.Multiply(mustNew(t, -2, 3))
+	p.Multiply(mustNew(t, 3, 4))
+	got, err := p.Fraction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "-1/4" {
+		t.Fatalf("1/2 * -2/3 * 3/4 = %v, want -1/4", got)
+	}
+}
+
+func TestProductAccumulator_EscalatesAndComesBack(t *testing.T) {
+	big := frac.NewI(uint64(1) << 40)
+	var p frac.ProductAccumulator
+	p.Multiply(big)
+	p.Multiply(big) // 2^80, doesn't fit
+	if _, err := p.Fraction(); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("2^80 should be out of range, got %v", err)
+	}
+
+	inv, err := big.Invert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Multiply(inv)
+	p.Multiply(mustNew(t, 3, 7))
+	got, err := p.Fraction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := mustNew(t, 3*(int64(1)<<40), 7)
+	if !got.Equal(want) {
+		t.Fatalf("product = %v, want %v", got, want)
+	}
+}
+
+// --- MinMaxTracker ---------------------------------------------------------
+
+func TestMinMaxTracker_Empty(t *testing.T) {
+	var m frac.MinMaxTracker
+	if _, ok := m.Min(); ok {
+		t.Fatal("Min() should not be ok before any observation")
+	}
+	if _, ok := m.Max(); ok {
+		t.Fatal("Max() should not be ok before any observation")
+	}
+}
+
+func TestMinMaxTracker(t *testing.T) {
+	var m frac.MinMaxTracker
+	for _, f := range []frac.Fraction{
+		mustNew(t, 1, 3), frac.Zero(), mustNew(t, -2, 7), mustNew(t, 5, 4), mustNew(t, -1, 4), frac.NewI(1),
+	} {
+		m.Observe(f)
+	}
+	min, ok := m.Min()
+	if !ok || min.String() != "-2/7" {
+		t.Fatalf("Min() = %v (ok=%v), want -2/7", min, ok)
+	}
+	max, ok := m.Max()
+	if !ok || max.String() != "5/4" {
+		t.Fatalf("Max() = %v (ok=%v), want 5/4", max, ok)
+	}
+}
+
+func TestMinMaxTracker_Single(t *testing.T) {
+	var m frac.MinMaxTracker
+	m.Observe(mustNew(t, -3, 5))
+	min, _ := m.Min()
+	max, _ := m.Max()
+	if !min.Equal(max) || min.String() != "-3/5" {
+		t.Fatalf("single observation: min=%v max=%v, want both -3/5", min, max)
+	}
+}
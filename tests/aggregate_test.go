@@ -0,0 +1,349 @@
+package fraction_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- EvalPolynomial --------------------------------------------------------
+
+func TestEvalPolynomial(t *testing.T) {
+	// x^2 - 2 at 3/2 = 9/4 - 2 = 1/4
+	coeffs := []frac.Fraction{frac.NewI(1), frac.Zero(), frac.NewI(-2)}
+	got, err := frac.EvalPolynomial(coeffs, mustNew(t, 3, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "1/4" {
+		t.Fatalf("x^2 - 2 at 3/2 = %v, want 1/4", got)
+	}
+}
+
+func TestEvalPolynomial_Empty(t *testing.T) {
+	got, err := frac.EvalPolynomial(nil, mustNew(t, 3, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(frac.Zero()) {
+		t.Fatalf("empty polynomial = %v, want 0", got)
+	}
+}
+
+func TestEvalPolynomial_Overflow(t *testing.T) {
+	coeffs := []frac.Fraction{frac.NewI(1), frac.Zero(), frac.Zero(), frac.Zero()}
+	_, err := frac.EvalPolynomial(coeffs, frac.NewI(uint64(1)<<32))
+	if !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("x^3 at 2^32 should overflow, got %v", err)
+	}
+}
+
+// --- Fold ------------------------------------------------------------------
+
+func TestFold(t *testing.T) {
+	fs := []frac.Fraction{mustNew(t, 1, 2), mustNew(t, 1, 3), mustNew(t, 1, 6)}
+
+	sum, err := frac.Fold(fs, frac.Zero(), frac.Add)
+	if err != nil || sum.String() != "1" {
+		t.Fatalf("Fold(%v, 0, Add) = %v, %v, want 1", fs, sum, err)
+	}
+
+	// Custom reduction: the largest gap between consecutive values, carrying the previous value in a closure
+	prev := fs[0]
+	maxGap, err := frac.Fold(fs[1:], frac.Zero(), func(acc, x frac.Fraction) (frac.Fraction, error) {
+		gap, err := frac.Subtract(prev, x)
+		prev = x
+		if err != nil {
+			return acc, err
+		}
+		if gap = gap.Abs(); gap.Greater(acc) {
+			return gap, nil
+		}
+		return acc, nil
+	})
+	if err != nil || maxGap.String() != "1/6" {
+		t.Fatalf("max gap fold = %v, %v, want 1/6", maxGap, err)
+	}
+
+	if got, err := frac.Fold(nil, mustNew(t, 2, 5), frac.Multiply); err != nil || got.String() != "2/5" {
+		t.Fatalf("Fold(nil, 2/5, Multiply) = %v, %v, want 2/5", got, err)
+	}
+}
+
+func TestFold_ErrorMidway(t *testing.T) {
+	fs := []frac.Fraction{frac.NewI(2), frac.Zero(), frac.NewI(3)}
+	calls := 0
+	_, err := frac.Fold(fs, frac.One(), func(acc, x frac.Fraction) (frac.Fraction, error) {
+		calls++
+		return frac.Divide(acc, x)
+	})
+	if !errors.Is(err, frac.ErrZeroDenominator) {
+		t.Fatalf("Fold error = %v, want ErrZeroDenominator", err)
+	}
+	if calls != 2 {
+		t.Fatalf("fn was called %d times, want 2", calls)
+	}
+}
+
+// --- Differences -----------------------------------------------------------
+
+func TestDifferences(t *testing.T) {
+	// Arithmetic sequence with a step of 1/3
+	seq, err := mustNew(t, 1, 3).Multiples(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diffs, err := frac.Differences(seq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != len(seq)-1 {
+		t.Fatalf("len(Differences(%v)) = %d, want %d", seq, len(diffs), len(seq)-1)
+	}
+	for i, d := range diffs {
+		if !d.Equal(mustNew(t, 1, 3)) {
+			t.Fatalf("Differences(%v)[%d] = %v, want 1/3", seq, i, d)
+		}
+	}
+
+	got, err := frac.Differences([]frac.Fraction{mustNew(t, 1, 2), mustNew(t, 1, 4), frac.One()})
+	if err != nil || fmt.Sprint(got) != "[-1/4 3/4]" {
+		t.Fatalf("Differences([1/2 1/4 1]) = %v, %v, want [-1/4 3/4]", got, err)
+	}
+
+	for _, fs := range [][]frac.Fraction{nil, {frac.One()}} {
+		if got, err := frac.Differences(fs); err != nil || got == nil || len(got) != 0 {
+			t.Fatalf("Differences(%v) = %#v, %v, want an empty slice", fs, got, err)
+		}
+	}
+
+	huge := frac.NewI(uint64(1) << 63)
+	if _, err := frac.Differences([]frac.Fraction{huge.Negate(), huge}); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("overflowing difference error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// --- NormalizeToSum --------------------------------------------------------
+
+func TestNormalizeToSum(t *testing.T) {
+	got, err := frac.NormalizeToSum([]frac.Fraction{frac.NewI(1), frac.NewI(1), frac.NewI(2)}, frac.One())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"1/4", "1/4", "1/2"}
+	for i := range want {
+		if got[i].String() != want[i] {
+			t.Fatalf("NormalizeToSum([1 1 2], 1)[%d] = %v, want %s", i, got[i], want[i])
+		}
+	}
+
+	got, err = frac.NormalizeToSum([]frac.Fraction{mustNew(t, 1, 3), mustNew(t, 1, 6), mustNew(t, -1, 4)}, frac.NewI(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := frac.Zero()
+	for _, f := range got {
+		sum, _ = frac.Add(sum, f)
+	}
+	if sum.String() != "3" {
+		t.Fatalf("normalized values add up to %v, want 3", sum)
+	}
+}
+
+func TestNormalizeToSum_ZeroSum(t *testing.T) {
+	cases := [][]frac.Fraction{
+		nil,
+		{mustNew(t, 1, 2), mustNew(t, -1, 2)},
+	}
+	for _, fs := range cases {
+		if _, err := frac.NormalizeToSum(fs, frac.One()); !errors.Is(err, frac.ErrDivideByZero) {
+			t.Fatalf("NormalizeToSum(%v) error = %v, want ErrDivideByZero", fs, err)
+		}
+	}
+}
+
+// --- ParallelCombine -------------------------------------------------------
+
+func TestParallelCombine(t *testing.T) {
+	cases := []struct {
+		fs   []frac.Fraction
+		want string
+	}{
+		{[]frac.Fraction{frac.NewI(2), frac.NewI(2)}, "1"},
+		{[]frac.Fraction{mustNew(t, 1, 2), mustNew(t, 1, 2)}, "1/4"},
+		{[]frac.Fraction{frac.NewI(3), frac.NewI(6)}, "2"},
+		{[]frac.Fraction{frac.NewI(5)}, "5"},
+		{[]frac.Fraction{frac.NewI(1), frac.NewI(2), frac.NewI(3)}, "6/11"},
+	}
+	for _, c := range cases {
+		got, err := frac.ParallelCombine(c.fs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("ParallelCombine(%v) = %v, want %s", c.fs, got, c.want)
+		}
+	}
+}
+
+func TestParallelCombine_Errors(t *testing.T) {
+	if _, err := frac.ParallelCombine(); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("empty input error = %v, want ErrInvalid", err)
+	}
+	if _, err := frac.ParallelCombine(frac.NewI(2), frac.Zero()); !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("zero element error = %v, want ErrDivideByZero", err)
+	}
+	if _, err := frac.ParallelCombine(frac.NewI(2), frac.NewI(-2)); !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("cancelling reciprocals error = %v, want ErrDivideByZero", err)
+	}
+}
+
+// --- SumValues / MaxByValue ------------------------------------------------
+
+func TestSumValues(t *testing.T) {
+	m := map[string]frac.Fraction{
+		"a": mustNew(t, 1, 2),
+		"b": mustNew(t, 2, 4),
+		"c": mustNew(t, -1, 3),
+		"d": mustNew(t, 1, 3),
+	}
+	got, err := frac.SumValues(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "1" {
+		t.Fatalf("SumValues = %v, want 1", got)
+	}
+
+	if _, err := frac.SumValues(nil); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("empty map error = %v, want ErrInvalid", err)
+	}
+}
+
+func TestMaxByValue(t *testing.T) {
+	m := map[string]frac.Fraction{
+		"low":    mustNew(t, -3, 4),
+		"second": mustNew(t, 3, 4),
+		"first":  mustNew(t, 6, 8), // same value as "second"
+		"mid":    mustNew(t, 1, 2),
+	}
+	for range 10 { // map order changes between runs, the answer must not
+		key, val, err := frac.MaxByValue(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key != "first" || val.String() != "3/4" {
+			t.Fatalf("MaxByValue = %q, %v, want \"first\", 3/4", key, val)
+		}
+	}
+
+	if _, _, err := frac.MaxByValue(map[string]frac.Fraction{}); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("empty map error = %v, want ErrInvalid", err)
+	}
+}
+
+// --- Multiples -------------------------------------------------------------
+
+func TestMultiples(t *testing.T) {
+	cases := []struct {
+		f    frac.Fraction
+		n    int
+		want string
+	}{
+		{mustNew(t, 1, 3), 4, "[1/3 2/3 1 4/3]"},
+		{mustNew(t, -3, 4), 3, "[-3/4 -3/2 -9/4]"},
+		{frac.Zero(), 2, "[0 0]"},
+		{mustNew(t, 1, 2), 1, "[1/2]"},
+		{mustNew(t, 1, 2), 0, "[]"},
+	}
+	for _, c := range cases {
+		got, err := c.f.Multiples(c.n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != c.n || fmt.Sprint(got) != c.want {
+			t.Fatalf("(%v).Multiples(%d) = %v, want %s", c.f, c.n, got, c.want)
+		}
+	}
+}
+
+func TestMultiples_Errors(t *testing.T) {
+	if _, err := frac.One().Multiples(-1); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("negative n error = %v, want ErrInvalid", err)
+	}
+	huge := frac.NewI(uint64(1) << 62)
+	if _, err := huge.Multiples(5); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("overflowing multiples error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// --- DivideEqually / AllocateIntegers --------------------------------------
+
+func TestDivideEqually(t *testing.T) {
+	cases := []struct {
+		f    frac.Fraction
+		n    int
+		want string
+	}{
+		{frac.NewI(7), 3, "[7/3 7/3 7/3]"},
+		{mustNew(t, 3, 4), 2, "[3/8 3/8]"},
+		{mustNew(t, -1, 2), 4, "[-1/8 -1/8 -1/8 -1/8]"},
+		{frac.Zero(), 2, "[0 0]"},
+		{mustNew(t, 5, 6), 1, "[5/6]"},
+	}
+	for _, c := range cases {
+		got, err := c.f.DivideEqually(c.n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := fmt.Sprint(got); s != c.want {
+			t.Fatalf("(%v).DivideEqually(%d) = %s, want %s", c.f, c.n, s, c.want)
+		}
+		if sum, err := frac.Fold(got, frac.Zero(), frac.Add); err != nil || !sum.Equal(c.f) {
+			t.Fatalf("(%v).DivideEqually(%d) adds up to %v, %v", c.f, c.n, sum, err)
+		}
+	}
+}
+
+func TestAllocateIntegers(t *testing.T) {
+	cases := []struct {
+		f    frac.Fraction
+		n    int
+		want string
+	}{
+		{frac.NewI(7), 3, "[3 2 2]"},
+		{frac.NewI(6), 3, "[2 2 2]"},
+		{mustNew(t, 15, 2), 3, "[3 3 2]"},
+		{mustNew(t, 22, 3), 4, "[2 2 2 1]"},
+		{frac.NewI(-7), 3, "[-2 -2 -3]"},
+		{mustNew(t, -1, 2), 2, "[0 -1]"},
+		{frac.NewI(2), 5, "[1 1 0 0 0]"},
+		{frac.Zero(), 3, "[0 0 0]"},
+	}
+	for _, c := range cases {
+		got, err := c.f.AllocateIntegers(c.n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := fmt.Sprint(got); s != c.want {
+			t.Fatalf("(%v).AllocateIntegers(%d) = %s, want %s", c.f, c.n, s, c.want)
+		}
+	}
+}
+
+func TestDivideEqually_AllocateIntegers_Errors(t *testing.T) {
+	f := frac.NewI(7)
+	for _, n := range []int{0, -3} {
+		if _, err := f.DivideEqually(n); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("DivideEqually(%d) error = %v, want ErrInvalid", n, err)
+		}
+		if _, err := f.AllocateIntegers(n); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("AllocateIntegers(%d) error = %v, want ErrInvalid", n, err)
+		}
+	}
+	if _, err := frac.NewI(uint64(1) << 63).AllocateIntegers(2); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("huge total error = %v, want ErrOutOfRange", err)
+	}
+}
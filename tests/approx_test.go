@@ -0,0 +1,701 @@
+package fraction_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- ApproximationError ----------------------------------------------------
+
+func TestApproximationError(t *testing.T) {
+	// 22/7 overshoots 3/1 by 1/7, so the residual is -1/7
+	got, err := frac.ApproximationError(frac.NewI(3), mustNew(t, 22, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "-1/7" {
+		t.Fatalf("ApproximationError(3, 22/7) = %v, want -1/7", got)
+	}
+
+	got, err = frac.ApproximationError(mustNew(t, 1, 3), mustNew(t, 33, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "1/300" {
+		t.Fatalf("ApproximationError(1/3, 33/100) = %v, want 1/300", got)
+	}
+
+	got, err = frac.ApproximationError(mustNew(t, 1, 3), mustNew(t, 2, 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(frac.Zero()) {
+		t.Fatalf("exact approximation should have zero error, got %v", got)
+	}
+}
+
+func TestApproximationError_OutOfRange(t *testing.T) {
+	_, err := frac.ApproximationError(mustNew(t, 1, 4294967311), mustNew(t, 1, 4294967357))
+	if !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("expected ErrOutOfRange, got %v", err)
+	}
+}
+
+// --- ApproximationQuality --------------------------------------------------
+
+func TestApproximationQuality(t *testing.T) {
+	pi := mustNew(t, 3141592653589793, 1000000000000000)
+
+	q1, err := frac.ApproximationQuality(mustNew(t, 22, 7), pi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q2, err := frac.ApproximationQuality(mustNew(t, 355, 113), pi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q2.Less(q1) {
+		t.Fatalf("355/113 scored %v and 22/7 scored %v, want 355/113 to be better", q2, q1)
+	}
+	if f := q1.Float64(); f < 0.0619 || f > 0.0620 {
+		t.Fatalf("ApproximationQuality(22/7, pi) = %v (%v), want about 0.0619", q1, f)
+	}
+	if f := q2.Float64(); f < 0.00340 || f > 0.00341 {
+		t.Fatalf("ApproximationQuality(355/113, pi) = %v (%v), want about 0.0034", q2, f)
+	}
+
+	cases := []struct {
+		approx, target frac.Fraction
+		want           string
+	}{
+		{frac.NewI(3), mustNew(t, 22, 7), "1/7"},
+		{mustNew(t, 22, 7), frac.NewI(3), "7"},
+		{mustNew(t, 1, 3), mustNew(t, 1, 3), "0"},
+		{mustNew(t, -1, 2), mustNew(t, -1, 3), "2/3"},
+	}
+	for _, c := range cases {
+		got, err := frac.ApproximationQuality(c.approx, c.target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("ApproximationQuality(%v, %v) = %v, want %s", c.approx, c.target, got, c.want)
+		}
+	}
+
+	// (2^40)^2 times a difference with a coprime denominator doesn't fit anymore
+	if _, err := frac.ApproximationQuality(mustNew(t, 1, 1<<40), mustNew(t, 1, 3)); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("huge score error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// --- SimplestBetween -------------------------------------------------------
+
+func TestSimplestBetween(t *testing.T) {
+	cases := []struct {
+		a, b frac.Fraction
+		want string
+	}{
+		{mustNew(t, 1, 3), mustNew(t, 1, 2), "2/5"},
+		{mustNew(t, -1, 2), mustNew(t, -1, 3), "-2/5"},
+		{mustNew(t, -1, 2), mustNew(t, 1, 3), "0"},
+		{frac.Zero(), mustNew(t, 1, 3), "1/4"},
+		{mustNew(t, -1, 3), frac.Zero(), "-1/4"},
+		{mustNew(t, 3, 2), mustNew(t, 7, 2), "2"},
+		{frac.NewI(1), frac.NewI(2), "3/2"},
+		{mustNew(t, 3, 1), mustNew(t, 22, 7), "25/8"},
+		{mustNew(t, 314, 100), mustNew(t, 315, 100), "22/7"},
+		{mustNew(t, 3141592, 1000000), mustNew(t, 3141593, 1000000), "355/113"},
+	}
+	for _, c := range cases {
+		got, err := frac.SimplestBetween(c.a, c.b)
+		if err != nil {
+			t.Fatalf("SimplestBetween(%v, %v): %v", c.a, c.b, err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("SimplestBetween(%v, %v) = %v, want %s", c.a, c.b, got, c.want)
+		}
+		if !got.Greater(c.a) || !got.Less(c.b) {
+			t.Fatalf("SimplestBetween(%v, %v) = %v is not strictly inside", c.a, c.b, got)
+		}
+	}
+}
+
+func TestSimplestBetween_Invalid(t *testing.T) {
+	a := mustNew(t, 1, 2)
+	if _, err := frac.SimplestBetween(a, a); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("equal bounds error = %v, want ErrInvalid", err)
+	}
+	if _, err := frac.SimplestBetween(a, mustNew(t, 1, 3)); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("reversed bounds error = %v, want ErrInvalid", err)
+	}
+}
+
+// --- SimplestWithin --------------------------------------------------------
+
+func TestSimplestWithin(t *testing.T) {
+	pi := mustNew(t, 355, 113)
+	cases := []struct {
+		f, tol frac.Fraction
+		want   string
+	}{
+		{pi, mustNew(t, 1, 100), "22/7"},
+		{pi, mustNew(t, 1, 5), "3"},
+		{pi, mustNew(t, 1, 1000), "201/64"},
+		{pi, mustNew(t, 1, 791), "22/7"}, // exactly on the bound
+		{pi, mustNew(t, 1, 10000), "333/106"},
+		{pi, mustNew(t, 1, 10000000), "355/113"},
+		{mustNew(t, -355, 113), mustNew(t, 1, 100), "-22/7"},
+		{mustNew(t, 1, 3), mustNew(t, 1, 2), "0"},
+		{mustNew(t, 1, 2), mustNew(t, 1, 2), "0"}, // the bound 0 is simpler than anything inside (0, 1)
+		{mustNew(t, 5, 4), mustNew(t, 1, 4), "1"},
+		{mustNew(t, 7, 12), mustNew(t, 1, 12), "1/2"},
+		{mustNew(t, 3, 10), mustNew(t, 1, 30), "1/3"},
+	}
+	for _, c := range cases {
+		got, err := c.f.SimplestWithin(c.tol)
+		if err != nil {
+			t.Fatalf("(%v).SimplestWithin(%v): %v", c.f, c.tol, err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("(%v).SimplestWithin(%v) = %v, want %s", c.f, c.tol, got, c.want)
+		}
+	}
+}
+
+func TestSimplestWithin_Invalid(t *testing.T) {
+	f := mustNew(t, 1, 2)
+	for _, tol := range []frac.Fraction{frac.Zero(), mustNew(t, -1, 100)} {
+		if _, err := f.SimplestWithin(tol); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("SimplestWithin(%v) error = %v, want ErrInvalid", tol, err)
+		}
+	}
+}
+
+// --- SnapToFarey -----------------------------------------------------------
+
+func TestSnapToFarey(t *testing.T) {
+	cases := []struct {
+		f     frac.Fraction
+		order uint64
+		want  string
+	}{
+		{mustNew(t, 3, 10), 5, "1/3"},
+		{mustNew(t, -3, 10), 5, "-1/3"},
+		{mustNew(t, 3, 10), 10, "3/10"},
+		{mustNew(t, 3141592653, 1000000000), 7, "22/7"},
+		{mustNew(t, 3141592653, 1000000000), 113, "355/113"},
+		{mustNew(t, 3141592653, 1000000000), 1, "3"},
+		{mustNew(t, 7, 4), 3, "5/3"},
+		{mustNew(t, 1, 1000), 5, "0"},
+		{mustNew(t, 1, 4), 2, "0"}, // tie between 0 and 1/2, 0 has the smaller denominator
+	}
+	for _, c := range cases {
+		got, err := c.f.SnapToFarey(c.order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("(%v).SnapToFarey(%d) = %v, want %s", c.f, c.order, got, c.want)
+		}
+	}
+}
+
+func TestSnapToFarey_ZeroOrder(t *testing.T) {
+	if _, err := mustNew(t, 1, 3).SnapToFarey(0); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("order 0 error = %v, want ErrInvalid", err)
+	}
+}
+
+func TestSnapToFarey_BruteForce(t *testing.T) {
+	for n := int64(-40); n <= 40; n++ {
+		f := mustNew(t, n, 37)
+		for order := uint64(1); order <= 12; order++ {
+			got, err := f.SnapToFarey(order)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dist, _ := frac.Subtract(got, f)
+			for d := uint64(1); d <= order; d++ {
+				for k := int64(-20); k <= 20; k++ {
+					cand := mustNew(t, k, int64(d))
+					cdist, _ := frac.Subtract(cand, f)
+					if cdist.Abs().Less(dist.Abs()) {
+						t.Fatalf("(%v).SnapToFarey(%d) = %v, but %v is closer", f, order, got, cand)
+					}
+				}
+			}
+		}
+	}
+}
+
+// --- BracketingConvergents -------------------------------------------------
+
+func TestBracketingConvergents(t *testing.T) {
+	cases := []struct {
+		f            frac.Fraction
+		maxDen       uint64
+		lower, upper string
+	}{
+		{mustNew(t, 1, 3), 1, "0", "1"},
+		{mustNew(t, 1, 3), 2, "0", "1/2"},
+		{mustNew(t, 1, 3), 3, "1/3", "1/3"},
+		{mustNew(t, 1, 3), 100, "1/3", "1/3"},
+		{mustNew(t, 3, 10), 5, "1/4", "1/3"},
+		{mustNew(t, 3, 10), 8, "2/7", "1/3"},
+		{mustNew(t, -3, 10), 5, "-1/3", "-1/4"},
+		{mustNew(t, 355, 113), 7, "3", "22/7"},
+	}
+	for _, c := range cases {
+		lower, upper, err := frac.BracketingConvergents(c.f, c.maxDen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lower.String() != c.lower || upper.String() != c.upper {
+			t.Fatalf("BracketingConvergents(%v, %d) = %v, %v, want %s, %s", c.f, c.maxDen, lower, upper, c.lower, c.upper)
+		}
+	}
+}
+
+func TestBracketingConvergents_Invalid(t *testing.T) {
+	f := mustNew(t, 1, 3)
+	if _, _, err := frac.BracketingConvergents(f, 0); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("BracketingConvergents(%v, 0) error = %v, want ErrInvalid", f, err)
+	}
+	if _, err := f.FloorToDenominator(0); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("FloorToDenominator(0) error = %v, want ErrInvalid", err)
+	}
+	if _, err := f.CeilToDenominator(0); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("CeilToDenominator(0) error = %v, want ErrInvalid", err)
+	}
+}
+
+func TestBracketingConvergents_BruteForce(t *testing.T) {
+	f := mustNew(t, 17, 61)
+	for maxDen := int64(1); maxDen <= 30; maxDen++ {
+		wantLower, wantUpper := frac.Zero(), frac.One()
+		for q := int64(1); q <= maxDen; q++ {
+			for p := int64(0); p <= q; p++ {
+				g := mustNew(t, p, q)
+				if g.LessEq(f) && g.Greater(wantLower) {
+					wantLower = g
+				}
+				if g.GreaterEq(f) && g.Less(wantUpper) {
+					wantUpper = g
+				}
+			}
+		}
+		lower, upper, err := frac.BracketingConvergents(f, uint64(maxDen))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !lower.Equal(wantLower) || !upper.Equal(wantUpper) {
+			t.Fatalf("BracketingConvergents(%v, %d) = %v, %v, want %v, %v", f, maxDen, lower, upper, wantLower, wantUpper)
+		}
+	}
+}
+
+// --- FloorToDenominator / CeilToDenominator --------------------------------
+
+func TestFloorCeilToDenominator(t *testing.T) {
+	cases := []struct {
+		f           frac.Fraction
+		maxDen      uint64
+		floor, ceil string
+	}{
+		{mustNew(t, 34, 100), 3, "1/3", "1/2"},
+		{mustNew(t, 32, 100), 3, "0", "1/3"},
+		{mustNew(t, 1, 3), 3, "1/3", "1/3"},
+		// Just above and just below the 1/2 grid line
+		{mustNew(t, 501, 1000), 4, "1/2", "2/3"},
+		{mustNew(t, 499, 1000), 4, "1/3", "1/2"},
+		{mustNew(t, 1000001, 2000000), 100, "1/2", "50/99"},
+		{mustNew(t, -501, 1000), 4, "-2/3", "-1/2"},
+		{mustNew(t, 7, 2), 1, "3", "4"},
+	}
+	for _, c := range cases {
+		if got, err := c.f.FloorToDenominator(c.maxDen); err != nil || got.String() != c.floor {
+			t.Fatalf("(%v).FloorToDenominator(%d) = %v, %v, want %s", c.f, c.maxDen, got, err, c.floor)
+		}
+		if got, err := c.f.CeilToDenominator(c.maxDen); err != nil || got.String() != c.ceil {
+			t.Fatalf("(%v).CeilToDenominator(%d) = %v, %v, want %s", c.f, c.maxDen, got, err, c.ceil)
+		}
+	}
+}
+
+// --- NearestHarmonic -------------------------------------------------------
+
+func TestNearestHarmonic(t *testing.T) {
+	cases := []struct {
+		f           frac.Fraction
+		maxHarmonic uint64
+		want        string
+	}{
+		{mustNew(t, 5, 2), 16, "2"},
+		{mustNew(t, 51, 20), 16, "3"},
+		{mustNew(t, 49, 20), 16, "2"},
+		{mustNew(t, 7, 3), 16, "2"},
+		{mustNew(t, 3, 10), 16, "1/3"},
+		{mustNew(t, 2, 9), 16, "1/5"},
+		{frac.One(), 16, "1"},
+		{mustNew(t, 3, 4), 16, "1"},
+		{frac.NewI(40), 16, "16"},
+		{mustNew(t, 1, 40), 16, "1/16"},
+		{frac.NewI(uint64(18446744073709551615)), uint64(18446744073709551615), "18446744073709551615"},
+	}
+	for _, c := range cases {
+		got, err := c.f.NearestHarmonic(c.maxHarmonic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("(%v).NearestHarmonic(%d) = %v, want %s", c.f, c.maxHarmonic, got, c.want)
+		}
+	}
+}
+
+func TestNearestHarmonic_Invalid(t *testing.T) {
+	cases := []struct {
+		f           frac.Fraction
+		maxHarmonic uint64
+	}{
+		{mustNew(t, 5, 2), 0},
+		{frac.Zero(), 8},
+		{mustNew(t, -3, 2), 8},
+	}
+	for _, c := range cases {
+		if _, err := c.f.NearestHarmonic(c.maxHarmonic); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("(%v).NearestHarmonic(%d) error = %v, want ErrInvalid", c.f, c.maxHarmonic, err)
+		}
+	}
+}
+
+// --- NearestGeometric ------------------------------------------------------
+
+func TestNearestGeometric(t *testing.T) {
+	two := frac.NewI(2)
+	cases := []struct {
+		f, base frac.Fraction
+		maxExp  int
+		want    string
+		wantK   int
+	}{
+		// Octaves
+		{frac.NewI(3), two, 10, "4", 2},
+		{mustNew(t, 3, 2), two, 10, "2", 1},
+		{mustNew(t, 5, 4), two, 10, "1", 0},
+		{mustNew(t, 1, 3), two, 10, "1/4", -2},
+		{mustNew(t, 11, 16), two, 10, "1/2", -1},
+		{frac.NewI(1000), two, 10, "1024", 10},
+		{frac.NewI(100), two, 3, "8", 3},
+		{mustNew(t, 1, 100), two, 3, "1/8", -3},
+		{frac.One(), two, 0, "1", 0},
+		{frac.NewI(2), frac.NewI(4), 5, "1", 0}, // exact tie, the lower power wins
+		{frac.NewI(2), mustNew(t, 3, 2), 5, "9/4", 2},
+	}
+	for _, c := range cases {
+		got, k, err := c.f.NearestGeometric(c.base, c.maxExp, 1000)
+		if err != nil {
+			t.Fatalf("(%v).NearestGeometric(%v, %d): %v", c.f, c.base, c.maxExp, err)
+		}
+		if got.String() != c.want || k != c.wantK {
+			t.Fatalf("(%v).NearestGeometric(%v, %d) = %v, %d, want %s, %d", c.f, c.base, c.maxExp, got, k, c.want, c.wantK)
+		}
+	}
+
+	// (3/2)^20 = 3486784401/1048576 gets approximated when the denominator doesn't fit
+	got, k, err := frac.NewI(3000).NearestGeometric(mustNew(t, 3, 2), 30, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k != 20 || got.Denominator() > 100 || got.Float64() < 3325 || got.Float64() > 3326 {
+		t.Fatalf("(3000).NearestGeometric(3/2, 30, 100) = %v, %d, want about 3325.26 and 20", got, k)
+	}
+}
+
+func TestNearestGeometric_Invalid(t *testing.T) {
+	two := frac.NewI(2)
+	cases := []struct {
+		f, base frac.Fraction
+		maxExp  int
+		maxDen  uint64
+	}{
+		{frac.Zero(), two, 10, 100},
+		{mustNew(t, -3, 2), two, 10, 100},
+		{mustNew(t, 3, 2), frac.One(), 10, 100},
+		{mustNew(t, 3, 2), mustNew(t, 1, 2), 10, 100},
+		{mustNew(t, 3, 2), frac.NewI(-2), 10, 100},
+		{mustNew(t, 3, 2), two, -1, 100},
+		{mustNew(t, 3, 2), two, 10, 0},
+	}
+	for _, c := range cases {
+		if _, _, err := c.f.NearestGeometric(c.base, c.maxExp, c.maxDen); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("(%v).NearestGeometric(%v, %d, %d) error = %v, want ErrInvalid", c.f, c.base, c.maxExp, c.maxDen, err)
+		}
+	}
+	nearOne := mustNew(t, 1<<40+1, 1<<40)
+	if _, _, err := frac.NewI(1000).NearestGeometric(nearOne, 1<<20, 100); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("huge power error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// --- FareyRank -------------------------------------------------------------
+
+func TestFareyRank(t *testing.T) {
+	// Farey sequence of order 5
+	sequence := []frac.Fraction{
+		frac.Zero(), mustNew(t, 1, 5), mustNew(t, 1, 4), mustNew(t, 1, 3), mustNew(t, 2, 5), mustNew(t, 1, 2),
+		mustNew(t, 3, 5), mustNew(t, 2, 3), mustNew(t, 3, 4), mustNew(t, 4, 5), frac.One(),
+	}
+	for want, f := range sequence {
+		got, err := frac.FareyRank(f, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("FareyRank(%v, 5) = %d, want %d", f, got, want)
+		}
+	}
+
+	// |F_n| = 1 + sum of phi(k), |F_100| = 3045
+	if got, err := frac.FareyRank(frac.One(), 100); err != nil || got != 3044 {
+		t.Fatalf("FareyRank(1, 100) = %d, %v, want 3044", got, err)
+	}
+	// By symmetry, 1/2 sits in the middle
+	if got, err := frac.FareyRank(mustNew(t, 1, 2), 100); err != nil || got != 1522 {
+		t.Fatalf("FareyRank(1/2, 100) = %d, %v, want 1522", got, err)
+	}
+}
+
+func TestFareyRank_Invalid(t *testing.T) {
+	cases := []struct {
+		f     frac.Fraction
+		order uint64
+	}{
+		{mustNew(t, 1, 2), 0},
+		{mustNew(t, 1, 7), 5},
+		{mustNew(t, -1, 2), 5},
+		{mustNew(t, 3, 2), 5},
+	}
+	for _, c := range cases {
+		if _, err := frac.FareyRank(c.f, c.order); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("FareyRank(%v, %d) error = %v, want ErrInvalid", c.f, c.order, err)
+		}
+	}
+	for _, order := range []uint64{1<<24 + 1, 1 << 32, 1 << 40} {
+		if _, err := frac.FareyRank(mustNew(t, 1, 2), order); !errors.Is(err, frac.ErrOutOfRange) {
+			t.Fatalf("order %d error = %v, want ErrOutOfRange", order, err)
+		}
+	}
+}
+
+// --- FromFloatRatio --------------------------------------------------------
+
+func TestFromFloatRatio(t *testing.T) {
+	cases := []struct {
+		num, den float64
+		maxDen   uint64
+		want     string
+	}{
+		{0.3, 0.9, 100, "1/3"},
+		{1, -3, 1000, "-1/3"},
+		{-0.75, -0.25, 10, "3"},
+		{0, 5, 10, "0"},
+		{3.14159265, 1, 7, "22/7"},
+	}
+	for _, c := range cases {
+		got, err := frac.FromFloatRatio(c.num, c.den, c.maxDen)
+		if err != nil {
+			t.Fatalf("FromFloatRatio(%g, %g, %d): %v", c.num, c.den, c.maxDen, err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("FromFloatRatio(%g, %g, %d) = %v, want %s", c.num, c.den, c.maxDen, got, c.want)
+		}
+	}
+}
+
+func TestFromFloatRatio_Errors(t *testing.T) {
+	cases := []struct {
+		num, den float64
+		maxDen   uint64
+		want     error
+	}{
+		{1, 0, 10, frac.ErrDivideByZero},
+		{math.NaN(), 1, 10, frac.ErrInvalid},
+		{1, math.Inf(-1), 10, frac.ErrInvalid},
+		{1, 2, 0, frac.ErrInvalid},
+		{1e300, 1e-10, 10, frac.ErrOutOfRange},
+	}
+	for _, c := range cases {
+		if _, err := frac.FromFloatRatio(c.num, c.den, c.maxDen); !errors.Is(err, c.want) {
+			t.Fatalf("FromFloatRatio(%g, %g, %d) error = %v, want %v", c.num, c.den, c.maxDen, err, c.want)
+		}
+	}
+}
+
+// --- GeometricMean ---------------------------------------------------------
+
+func TestGeometricMean(t *testing.T) {
+	cases := []struct {
+		fs     []frac.Fraction
+		maxDen uint64
+		want   string
+	}{
+		{[]frac.Fraction{mustNew(t, 1, 2), frac.NewI(2)}, 10, "1"},
+		{[]frac.Fraction{frac.NewI(2), frac.NewI(8)}, 1000, "4"},
+		{[]frac.Fraction{frac.NewI(4), frac.NewI(9)}, 1000, "6"},
+		{[]frac.Fraction{frac.NewI(1), frac.NewI(2)}, 12, "17/12"}, // sqrt(2)
+		{[]frac.Fraction{mustNew(t, 1, 27)}, 100, "1/27"},
+	}
+	for _, c := range cases {
+		got, err := frac.GeometricMean(c.fs, c.maxDen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("GeometricMean(%v, %d) = %v, want %s", c.fs, c.maxDen, got, c.want)
+		}
+	}
+}
+
+func TestGeometricMean_HugeProduct(t *testing.T) {
+	// The product is 1000^200 = 10^600, way past what a float64 can hold
+	fs := make([]frac.Fraction, 200)
+	for i := range fs {
+		fs[i] = frac.NewI(1000)
+	}
+	got, err := frac.GeometricMean(fs, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "1000" {
+		t.Fatalf("GeometricMean = %v, want 1000", got)
+	}
+}
+
+func TestGeometricMean_Invalid(t *testing.T) {
+	cases := [][]frac.Fraction{
+		nil,
+		{frac.NewI(2), frac.Zero()},
+		{frac.NewI(2), frac.NewI(-8)},
+	}
+	for _, fs := range cases {
+		if _, err := frac.GeometricMean(fs, 100); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("GeometricMean(%v) error = %v, want ErrInvalid", fs, err)
+		}
+	}
+}
+
+// --- Log2Approx / LnApprox -------------------------------------------------
+
+func TestLog2Approx(t *testing.T) {
+	cases := []struct {
+		f      frac.Fraction
+		maxDen uint64
+		want   string
+	}{
+		{frac.NewI(8), 1, "3"},
+		{frac.One(), 1, "0"},
+		{mustNew(t, 1, 4), 1, "-2"},
+		{frac.NewI(uint64(1) << 63), 1, "63"},
+		{frac.MustNew(uint64(1), uint64(1)<<63), 1, "-63"},
+		{frac.NewI(3), 10, "8/5"}, // log2(3) = 1.58496...
+		{frac.NewI(3), 1000, "1054/665"},
+		{mustNew(t, 1, 3), 10, "-8/5"},
+	}
+	for _, c := range cases {
+		got, err := frac.Log2Approx(c.f, c.maxDen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("Log2Approx(%v, %d) = %v, want %s", c.f, c.maxDen, got, c.want)
+		}
+	}
+}
+
+func TestLnApprox(t *testing.T) {
+	cases := []struct {
+		f      frac.Fraction
+		maxDen uint64
+		want   string
+	}{
+		{frac.One(), 10, "0"},
+		{frac.NewI(2), 10, "7/10"}, // ln(2) = 0.693147...
+		{mustNew(t, 1, 2), 10, "-7/10"},
+		{frac.NewI(10), 100, "175/76"},
+	}
+	for _, c := range cases {
+		got, err := frac.LnApprox(c.f, c.maxDen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("LnApprox(%v, %d) = %v, want %s", c.f, c.maxDen, got, c.want)
+		}
+	}
+}
+
+func TestLogApprox_Invalid(t *testing.T) {
+	for _, f := range []frac.Fraction{frac.Zero(), mustNew(t, -1, 2)} {
+		if _, err := frac.Log2Approx(f, 10); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("Log2Approx(%v) error = %v, want ErrInvalid", f, err)
+		}
+		if _, err := frac.LnApprox(f, 10); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("LnApprox(%v) error = %v, want ErrInvalid", f, err)
+		}
+	}
+	if _, err := frac.Log2Approx(frac.NewI(3), 0); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("maxDen 0 error = %v, want ErrInvalid", err)
+	}
+}
+
+// --- ApproxPrimeDenominator ------------------------------------------------
+
+func TestApproxPrimeDenominator(t *testing.T) {
+	cases := []struct {
+		f        frac.Fraction
+		maxPrime uint64
+		want     string
+	}{
+		{mustNew(t, 3141592653, 1000000000), 7, "22/7"},
+		{mustNew(t, 3141592653, 1000000000), 120, "355/113"},
+		{mustNew(t, 1, 4), 5, "1/5"}, // 1/4 isn't reachable, 1/5 and 1/3 are 1/20 and 1/12 away
+		{mustNew(t, -2, 3), 3, "-2/3"},
+		{mustNew(t, 1, 2), 2, "1/2"},
+		{mustNew(t, 5, 4), 2, "3/2"}, // round(5/2) goes away from zero
+		{mustNew(t, 5, 4), 3, "4/3"},
+	}
+	for _, c := range cases {
+		got, err := c.f.ApproxPrimeDenominator(c.maxPrime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("(%v).ApproxPrimeDenominator(%d) = %v, want %s", c.f, c.maxPrime, got, c.want)
+		}
+	}
+}
+
+func TestApproxPrimeDenominator_Errors(t *testing.T) {
+	for _, maxPrime := range []uint64{0, 1} {
+		if _, err := mustNew(t, 1, 3).ApproxPrimeDenominator(maxPrime); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("maxPrime %d error = %v, want ErrInvalid", maxPrime, err)
+		}
+	}
+	if _, err := frac.NewI(uint64(1) << 62).ApproxPrimeDenominator(5); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("huge value error = %v, want ErrOutOfRange", err)
+	}
+	// The bound itself still works
+	if got, err := mustNew(t, 1, 3).ApproxPrimeDenominator(1 << 24); err != nil || got.String() != "1/3" {
+		t.Fatalf("ApproxPrimeDenominator(2^24) = %v, %v, want 1/3", got, err)
+	}
+	for _, maxPrime := range []uint64{1<<24 + 1, 10000000000, math.MaxUint64} {
+		if _, err := mustNew(t, 1, 3).ApproxPrimeDenominator(maxPrime); !errors.Is(err, frac.ErrOutOfRange) {
+			t.Fatalf("maxPrime %d error = %v, want ErrOutOfRange", maxPrime, err)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package fraction_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- ComparatorAgainst -----------------------------------------------------
+
+func compareValues(t testing.TB) []frac.Fraction {
+	t.Helper()
+	var values []frac.Fraction
+	for n := int64(-20); n <= 20; n++ {
+		for d := int64(1); d <= 12; d++ {
+			f, err := frac.New(n*7919, d*104729)
+			if err != nil {
+				t.Fatal(err)
+			}
+			values = append(values, f)
+		}
+	}
+	return values
+}
+
+func TestComparatorAgainst_MatchesCmp(t *testing.T) {
+	values := compareValues(t)
+	for _, a := range values {
+		cmp := a.ComparatorAgainst()
+		for _, b := range values {
+			if got, want := cmp(b), frac.Cmp(a, b); got != want {
+				t.Fatalf("ComparatorAgainst(%v)(%v) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkCmp_Repeated(b *testing.B) {
+	values := compareValues(b)
+	target := values[len(values)/3]
+	b.ResetTimer()
+	for range b.N {
+		for _, v := range values {
+			frac.Cmp(target, v)
+		}
+	}
+}
+
+func BenchmarkComparatorAgainst(b *testing.B) {
+	values := compareValues(b)
+	cmp := values[len(values)/3].ComparatorAgainst()
+	b.ResetTimer()
+	for range b.N {
+		for _, v := range values {
+			cmp(v)
+		}
+	}
+}
+
+// --- CompareSlices ---------------------------------------------------------
+
+func TestCompareSlices(t *testing.T) {
+	half, third := mustNew(t, 1, 2), mustNew(t, 1, 3)
+	cases := []struct {
+		a, b []frac.Fraction
+		want int
+	}{
+		{nil, nil, 0},
+		{[]frac.Fraction{half, third}, []frac.Fraction{mustNew(t, 2, 4), mustNew(t, 2, 6)}, 0},
+		{[]frac.Fraction{third, half}, []frac.Fraction{half, third}, -1},
+		{[]frac.Fraction{half, half}, []frac.Fraction{half, third}, 1},
+		{[]frac.Fraction{half}, []frac.Fraction{half, third}, -1}, // prefix goes first
+		{[]frac.Fraction{half, third}, []frac.Fraction{half}, 1},
+		{nil, []frac.Fraction{frac.Zero()}, -1},
+		{[]frac.Fraction{frac.NewI(-1), frac.NewI(100)}, []frac.Fraction{frac.Zero()}, -1},
+	}
+	for _, c := range cases {
+		if got := frac.CompareSlices(c.a, c.b); got != c.want {
+			t.Fatalf("CompareSlices(%v, %v) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestCompareSlices_Sort(t *testing.T) {
+	rows := [][]frac.Fraction{
+		{frac.NewI(1), mustNew(t, 1, 2)},
+		{frac.NewI(1)},
+		{mustNew(t, -1, 2), frac.NewI(5)},
+		{frac.NewI(1), mustNew(t, 1, 3)},
+	}
+	slices.SortFunc(rows, frac.CompareSlices)
+	if got := fmt.Sprint(rows); got != "[[-1/2 5] [1] [1 1/3] [1 1/2]]" {
+		t.Fatalf("sorted rows = %s", got)
+	}
+}
+
+// --- CmpFast ---------------------------------------------------------------
+
+func TestCmpFast_MatchesCmp(t *testing.T) {
+	const big = uint64(1) << 62
+	values := compareValues(t)
+	// Neighbours whose Float64() values are equal or one ulp apart
+	values = append(values,
+		frac.MustNew(big, big+1),
+		frac.MustNew(big+1, big+2),
+		frac.MustNew(big-1, big),
+		frac.MustNew(uint64(18446744073709551615), uint64(18446744073709551614)),
+		frac.MustNew(uint64(18446744073709551614), uint64(18446744073709551613)),
+		frac.MustNew(uint64(1), uint64(18446744073709551615)),
+		frac.MustNew(uint64(1), uint64(18446744073709551614)),
+		frac.MustNew(big, 3).Negate(),
+		frac.MustNew(big+1, 3).Negate(),
+		frac.One(),
+		frac.Zero(),
+	)
+	for _, a := range values {
+		for _, b := range values {
+			if got, want := frac.CmpFast(a, b), frac.Cmp(a, b); got != want {
+				t.Fatalf("CmpFast(%v, %v) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkCmpFast(b *testing.B) {
+	values := compareValues(b)
+	target := values[len(values)/3]
+	b.ResetTimer()
+	for range b.N {
+		for _, v := range values {
+			frac.CmpFast(target, v)
+		}
+	}
+}
+
+// --- CmpRaw ----------------------------------------------------------------
+
+func TestCmpRaw_MatchesCmp(t *testing.T) {
+	values := compareValues(t)
+	for _, a := range values {
+		for n := int64(-6); n <= 6; n++ {
+			for d := uint64(1); d <= 8; d++ {
+				num := uint64(max(n, -n)) * 104729
+				want := frac.Cmp(a, frac.MustNew(n*104729, d))
+				got, err := a.CmpRaw(num, d, n < 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Fatalf("(%v).CmpRaw(%d, %d, %v) = %d, want %d", a, num, d, n < 0, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestCmpRaw_Edges(t *testing.T) {
+	if _, err := frac.One().CmpRaw(1, 0, false); !errors.Is(err, frac.ErrZeroDenominator) {
+		t.Fatalf("zero denominator error = %v, want ErrZeroDenominator", err)
+	}
+	// A zero numerator with the negative flag is still zero
+	if got, err := frac.Zero().CmpRaw(0, 5, true); err != nil || got != 0 {
+		t.Fatalf("(0).CmpRaw(0, 5, true) = %d, %v, want 0", got, err)
+	}
+	// Unreduced huge values compare exactly
+	const big = uint64(18446744073709551615)
+	if got, err := frac.One().CmpRaw(big, big-1, false); err != nil || got != -1 {
+		t.Fatalf("(1).CmpRaw(max, max-1, false) = %d, %v, want -1", got, err)
+	}
+	if got, err := frac.One().CmpRaw(big, big, true); err != nil || got != 1 {
+		t.Fatalf("(1).CmpRaw(max, max, true) = %d, %v, want 1", got, err)
+	}
+}
+
+func BenchmarkCmpRaw(b *testing.B) {
+	target := frac.MustNew(355, 113)
+	b.ResetTimer()
+	for i := range b.N {
+		target.CmpRaw(uint64(i%1000)*3, 339, i%2 == 0)
+	}
+}
+
+func BenchmarkCmp_NewEachIteration(b *testing.B) {
+	target := frac.MustNew(355, 113)
+	b.ResetTimer()
+	for i := range b.N {
+		n := int64(i%1000) * 3
+		if i%2 == 0 {
+			n = -n
+		}
+		g, _ := frac.New(n, 339)
+		frac.Cmp(target, g)
+	}
+}
+
+// --- FloatEqualButNotExact -------------------------------------------------
+
+func TestFloatEqualButNotExact(t *testing.T) {
+	const m = int64(9223372036854775807)
+	a := mustNew(t, m-1, m)
+	b := mustNew(t, m-2, m-1)
+	if a.Equal(b) {
+		t.Fatalf("%v and %v should be different fractions", a, b)
+	}
+	if !frac.FloatEqualButNotExact(a, b) {
+		t.Fatalf("FloatEqualButNotExact(%v, %v) = false, want true (floats %v and %v)", a, b, a.Float64(), b.Float64())
+	}
+	if got := frac.Cmp(a, b); got != 1 {
+		t.Fatalf("Cmp(%v, %v) = %d, want 1", a, b, got)
+	}
+
+	cases := []struct{ a, b frac.Fraction }{
+		{mustNew(t, 1, 3), mustNew(t, 1, 3)},
+		{mustNew(t, 1, 3), mustNew(t, 1, 2)},
+		{a, a},
+	}
+	for _, c := range cases {
+		if frac.FloatEqualButNotExact(c.a, c.b) {
+			t.Fatalf("FloatEqualButNotExact(%v, %v) = true, want false", c.a, c.b)
+		}
+	}
+}
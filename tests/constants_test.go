@@ -0,0 +1,95 @@
+package fraction_test
+
+import (
+	"math"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- MatchesConstant -------------------------------------------------------
+
+func TestMatchesConstant(t *testing.T) {
+	cases := []struct {
+		f, tol frac.Fraction
+		name   string
+		ok     bool
+	}{
+		{mustNew(t, 22, 7), frac.Zero(), "pi", true},
+		{mustNew(t, 355, 113), frac.Zero(), "pi", true},
+		{mustNew(t, 19, 7), frac.Zero(), "e", true},
+		{mustNew(t, 89, 55), frac.Zero(), "phi", true},
+		{mustNew(t, 314, 100), mustNew(t, 1, 100), "pi", true},
+		{mustNew(t, 272, 100), mustNew(t, 1, 100), "e", true},
+		{mustNew(t, 314, 100), mustNew(t, 1, 10000), "", false},
+		{mustNew(t, 1, 2), mustNew(t, 1, 10), "", false},
+	}
+	for _, c := range cases {
+		name, ok := c.f.MatchesConstant(c.tol)
+		if name != c.name || ok != c.ok {
+			t.Fatalf("(%v).MatchesConstant(%v) = %q, %v, want %q, %v", c.f, c.tol, name, ok, c.name, c.ok)
+		}
+	}
+}
+
+func TestMatchesConstant_Extended(t *testing.T) {
+	saved := frac.ConstantApproximations
+	defer func() { frac.ConstantApproximations = saved }()
+
+	frac.ConstantApproximations = append(frac.ConstantApproximations,
+		frac.ConstantApproximation{Name: "sqrt2", Value: mustNew(t, 99, 70)})
+	if name, ok := mustNew(t, 99, 70).MatchesConstant(frac.Zero()); !ok || name != "sqrt2" {
+		t.Fatalf("extended table match = %q, %v, want \"sqrt2\", true", name, ok)
+	}
+}
+
+// --- Pi / E / Phi ----------------------------------------------------------
+
+func TestConstantBuilders(t *testing.T) {
+	cases := []struct {
+		name string
+		got  frac.Fraction
+		want frac.Fraction
+	}{
+		{"Pi(7)", frac.Pi(7), mustNew(t, 22, 7)},
+		{"Pi(113)", frac.Pi(113), mustNew(t, 355, 113)},
+		{"Pi(100)", frac.Pi(100), mustNew(t, 311, 99)},
+		{"Pi(33215)", frac.Pi(33215), mustNew(t, 104348, 33215)},
+		{"Pi(1000000)", frac.Pi(1000000), mustNew(t, 3126535, 995207)},
+		{"Pi(1)", frac.Pi(1), mustNew(t, 3, 1)},
+		{"Pi(0)", frac.Pi(0), mustNew(t, 3, 1)},
+		{"E(7)", frac.E(7), mustNew(t, 19, 7)},
+		{"E(71)", frac.E(71), mustNew(t, 193, 71)},
+		{"Phi(55)", frac.Phi(55), mustNew(t, 89, 55)},
+		{"Phi(60)", frac.Phi(60), mustNew(t, 89, 55)},
+	}
+	for _, c := range cases {
+		if !c.got.Equal(c.want) {
+			t.Fatalf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	// Past the float64 precision the approximations keep improving, up to the uint64 bounds
+	for _, c := range []struct {
+		name string
+		got  frac.Fraction
+		want frac.Fraction
+	}{
+		{"Pi", frac.Pi(math.MaxUint64), mustNew(t, 2646693125139304345, 842468587426513207)},
+		{"E", frac.E(math.MaxUint64), mustNew(t, 5739439214861417731, 2111421691000680031)},
+		{"Phi", frac.Phi(math.MaxUint64), frac.MustNew(uint64(12200160415121876738), 7540113804746346429)},
+	} {
+		if !c.got.Equal(c.want) {
+			t.Fatalf("%s(MaxUint64) = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if got := frac.Phi(1_000_000_000_000); !got.Equal(mustNew(t, 1548008755920, 956722026041)) {
+		t.Fatalf("Phi(10^12) = %v, want 1548008755920/956722026041", got)
+	}
+
+	for _, f := range []frac.Fraction{frac.Pi(113), frac.E(71), frac.Phi(55)} {
+		if _, ok := f.MatchesConstant(frac.Zero()); !ok {
+			t.Fatalf("%v isn't in ConstantApproximations", f)
+		}
+	}
+}
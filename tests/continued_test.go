@@ -0,0 +1,244 @@
+package fraction_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- ContinuedFraction -----------------------------------------------------
+
+func TestContinuedFraction(t *testing.T) {
+	if got := mustNew(t, 415, 93).ContinuedFraction(); !slices.Equal(got, []uint64{4, 2, 6, 7}) {
+		t.Fatalf("ContinuedFraction(415/93) = %v, want [4 2 6 7]", got)
+	}
+	if got := frac.Zero().ContinuedFraction(); !slices.Equal(got, []uint64{0}) {
+		t.Fatalf("ContinuedFraction(0) = %v, want [0]", got)
+	}
+}
+
+func TestContinuedFractionString(t *testing.T) {
+	cases := map[string]frac.Fraction{
+		"[4; 2, 6, 7]":  mustNew(t, 415, 93),
+		"-[4; 2, 6, 7]": mustNew(t, -415, 93),
+		"[0]":           frac.Zero(),
+		"[5]":           frac.NewI(5),
+		"[0; 3]":        mustNew(t, 1, 3),
+		"[3; 7, 16]":    mustNew(t, 355, 113),
+	}
+	for want, f := range cases {
+		if got := f.ContinuedFractionString(); got != want {
+			t.Fatalf("ContinuedFractionString(%v) = %q, want %q", f, got, want)
+		}
+	}
+}
+
+// --- ContinuedFractionLength -----------------------------------------------
+
+func TestContinuedFractionLength(t *testing.T) {
+	cases := []struct {
+		f    frac.Fraction
+		want int
+	}{
+		{frac.Zero(), 1},
+		{frac.NewI(7), 1},
+		{frac.NewI(-7), 1},
+		{mustNew(t, 1, 2), 2},
+		{mustNew(t, 22, 7), 2},
+		{mustNew(t, 355, 113), 3},
+		{mustNew(t, -415, 93), 4},
+		// Consecutive Fibonacci numbers have the longest expansions, 89/55 is [1; 1, 1, 1, 1, 1, 1, 1, 2]
+		{mustNew(t, 89, 55), 9},
+	}
+	for _, c := range cases {
+		if got := c.f.ContinuedFractionLength(); got != c.want {
+			t.Fatalf("(%v).ContinuedFractionLength() = %d, want %d", c.f, got, c.want)
+		}
+		if got := len(c.f.ContinuedFraction()); got != c.want {
+			t.Fatalf("len((%v).ContinuedFraction()) = %d, want %d", c.f, got, c.want)
+		}
+	}
+}
+
+// --- EvalGeneralizedContinuedFraction --------------------------------------
+
+func TestEvalGeneralizedContinuedFraction(t *testing.T) {
+	// Simple continued fraction [4; 2, 6, 7] has every a equal to 1
+	one := frac.One()
+	got, err := frac.EvalGeneralizedContinuedFraction(
+		[]frac.Fraction{one, one, one},
+		[]frac.Fraction{frac.NewI(4), frac.NewI(2), frac.NewI(6), frac.NewI(7)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "415/93" {
+		t.Fatalf("[4; 2, 6, 7] = %v, want 415/93", got)
+	}
+
+	// pi = 4/(1 + 1^2/(3 + 2^2/(5 + 3^2/7))) truncated, a = [4, 1, 4, 9], b = [0, 1, 3, 5, 7]
+	got, err = frac.EvalGeneralizedContinuedFraction(
+		[]frac.Fraction{frac.NewI(4), frac.NewI(1), frac.NewI(4), frac.NewI(9)},
+		[]frac.Fraction{frac.NewI(0), frac.NewI(1), frac.NewI(3), frac.NewI(5), frac.NewI(7)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "160/51" {
+		t.Fatalf("truncated pi expansion = %v, want 160/51", got)
+	}
+
+	// Fractional terms: 1/2 + (1/3)/(1/4) = 11/6
+	got, err = frac.EvalGeneralizedContinuedFraction(
+		[]frac.Fraction{mustNew(t, 1, 3)},
+		[]frac.Fraction{mustNew(t, 1, 2), mustNew(t, 1, 4)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "11/6" {
+		t.Fatalf("1/2 + (1/3)/(1/4) = %v, want 11/6", got)
+	}
+}
+
+func TestEvalGeneralizedContinuedFraction_Errors(t *testing.T) {
+	one := frac.One()
+	if _, err := frac.EvalGeneralizedContinuedFraction(nil, nil); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("empty input error = %v, want ErrInvalid", err)
+	}
+	if _, err := frac.EvalGeneralizedContinuedFraction([]frac.Fraction{one, one}, []frac.Fraction{one, one}); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("mismatched lengths error = %v, want ErrInvalid", err)
+	}
+	// 1 + 1/(1 + 1/(-1)) divides by zero
+	_, err := frac.EvalGeneralizedContinuedFraction(
+		[]frac.Fraction{one, one},
+		[]frac.Fraction{one, one, frac.NewI(-1)},
+	)
+	if !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("zero partial denominator error = %v, want ErrDivideByZero", err)
+	}
+}
+
+// --- SimplerStep -----------------------------------------------------------
+
+func TestSimplerStep(t *testing.T) {
+	want := []frac.Fraction{mustNew(t, 22, 7), mustNew(t, 3, 1)}
+
+	// 355/113 is [3; 7, 16]
+	f := mustNew(t, 355, 113)
+	for _, w := range want {
+		next, ok := f.SimplerStep()
+		if !ok || !next.Equal(w) {
+			t.Fatalf("(%v).SimplerStep() = %v, %v, want %v, true", f, next, ok, w)
+		}
+		f = next
+	}
+	if next, ok := f.SimplerStep(); ok || !next.Equal(f) {
+		t.Fatalf("(%v).SimplerStep() = %v, %v, want %v, false", f, next, ok, f)
+	}
+}
+
+func TestSimplerStep_SignAndZero(t *testing.T) {
+	got, ok := mustNew(t, -415, 93).SimplerStep()
+	if !ok || !got.Equal(mustNew(t, -58, 13)) {
+		t.Fatalf("(-415/93).SimplerStep() = %v, %v, want -58/13, true", got, ok)
+	}
+	if got, ok := mustNew(t, -1, 3).SimplerStep(); !ok || !got.Equal(frac.Zero()) || got.IsNegative() {
+		t.Fatalf("(-1/3).SimplerStep() = %v, %v, want 0, true", got, ok)
+	}
+	if _, ok := frac.Zero().SimplerStep(); ok {
+		t.Fatal("(0).SimplerStep() reported a simpler form")
+	}
+}
+
+// --- SemiConvergents -------------------------------------------------------
+
+func TestSemiConvergents_Pi(t *testing.T) {
+	pi, err := frac.FromFloat64(math.Pi)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Best rational approximations of pi (OEIS A063674 / A063673)
+	want := "[3 13/4 16/5 19/6 22/7 179/57 201/64 223/71 245/78 267/85 289/92 311/99 333/106 355/113]"
+	got, err := pi.SemiConvergents(113)
+	if err != nil || fmt.Sprint(got) != want {
+		t.Fatalf("(pi).SemiConvergents(113) = %v, %v, want %s", got, err, want)
+	}
+	got, err = pi.Negate().SemiConvergents(7)
+	if err != nil || fmt.Sprint(got) != "[-3 -13/4 -16/5 -19/6 -22/7]" {
+		t.Fatalf("(-pi).SemiConvergents(7) = %v, %v", got, err)
+	}
+}
+
+func TestSemiConvergents(t *testing.T) {
+	cases := []struct {
+		f      frac.Fraction
+		maxDen uint64
+		want   string
+	}{
+		{mustNew(t, 9, 10), 1, "[1]"},
+		{mustNew(t, 9, 10), 10, "[1 5/6 6/7 7/8 8/9 9/10]"},
+		{mustNew(t, 1, 3), 0, "[0]"},
+		{mustNew(t, 1, 3), 100, "[0 1/2 1/3]"},
+		{frac.NewI(4), 10, "[4]"},
+		{frac.Zero(), 10, "[0]"},
+	}
+	for _, c := range cases {
+		got, err := c.f.SemiConvergents(c.maxDen)
+		if err != nil || fmt.Sprint(got) != c.want {
+			t.Fatalf("(%v).SemiConvergents(%d) = %v, %v, want %s", c.f, c.maxDen, got, err, c.want)
+		}
+	}
+}
+
+func TestSemiConvergents_LargeQuotient(t *testing.T) {
+	// 1/10^12 = [0; 10^12], so every 1/j with j above 5*10^11 is a best approximation
+	f := mustNew(t, 1, 1000000000000)
+	got, err := f.SemiConvergents(500000000010)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 11 || got[0] != frac.Zero() || got[10] != mustNew(t, 1, 500000000010) {
+		t.Fatalf("(%v).SemiConvergents(500000000010) = %v", f, got)
+	}
+	if _, err := f.SemiConvergents(1000000000000); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("(%v).SemiConvergents(10^12) error = %v, want ErrOutOfRange", f, err)
+	}
+}
+
+func TestSemiConvergents_BruteForce(t *testing.T) {
+	f := mustNew(t, 1393, 985) // close to sqrt(2)
+	got, err := f.SemiConvergents(985)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Walk every denominator and keep the fractions that beat everything before them
+	var want []frac.Fraction
+	var best frac.Fraction
+	for q := int64(1); q <= 985; q++ {
+		c, err := frac.Start(f).Mult(frac.NewI(q)).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		floor, ceil := c.Bracket()
+		for _, p := range []frac.Fraction{floor, ceil} {
+			cand, _ := frac.Divide(p, frac.NewI(q))
+			dist, _ := frac.Subtract(f, cand)
+			if len(want) == 0 || dist.Abs().Less(best) {
+				if len(want) > 0 && want[len(want)-1].Denominator() == cand.Denominator() {
+					want = want[:len(want)-1]
+				}
+				want, best = append(want, cand), dist.Abs()
+			}
+		}
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("(%v).SemiConvergents(985) = %v, want %v", f, got, want)
+	}
+}
@@ -0,0 +1,297 @@
+package fraction_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- FromPercentInt / FromBasisPoints --------------------------------------
+
+func TestFromPercentInt(t *testing.T) {
+	cases := map[int64]string{
+		0:   "0",
+		37:  "37/100",
+		50:  "1/2",
+		100: "1",
+		-25: "-1/4",
+		250: "5/2",
+	}
+	for p, want := range cases {
+		if got := frac.FromPercentInt(p).String(); got != want {
+			t.Fatalf("FromPercentInt(%d) = %s, want %s", p, got, want)
+		}
+	}
+}
+
+func TestFromBasisPoints(t *testing.T) {
+	cases := map[int64]string{
+		0:     "0",
+		25:    "1/400",
+		10000: "1",
+		-150:  "-3/200",
+	}
+	for bp, want := range cases {
+		if got := frac.FromBasisPoints(bp).String(); got != want {
+			t.Fatalf("FromBasisPoints(%d) = %s, want %s", bp, got, want)
+		}
+	}
+}
+
+// --- FromFloat64s ----------------------------------------------------------
+
+func TestFromFloat64s(t *testing.T) {
+	in := []float64{0.5, math.NaN(), -0.3, 3.14159}
+	want := []frac.Fraction{mustNew(t, 1, 2), frac.Zero(), mustNew(t, -3, 10), mustNew(t, 22, 7)}
+
+	got, errs := frac.FromFloat64s(in, 10)
+	if len(got) != len(in) || len(errs) != len(in) {
+		t.Fatalf("FromFloat64s returned %d fractions and %d errors, want %d of each", len(got), len(errs), len(in))
+	}
+	for i := range in {
+		wantErr := math.IsNaN(in[i])
+		if (errs[i] != nil) != wantErr || wantErr && !errors.Is(errs[i], frac.ErrInvalid) {
+			t.Fatalf("FromFloat64s[%d] error = %v, want invalid: %v", i, errs[i], wantErr)
+		}
+		if !got[i].Equal(want[i]) {
+			t.Fatalf("FromFloat64s[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if got, errs := frac.FromFloat64s(nil, 10); len(got) != 0 || len(errs) != 0 {
+		t.Fatalf("FromFloat64s(nil) = %v, %v, want empty slices", got, errs)
+	}
+}
+
+// --- NearestFloat64Fraction ------------------------------------------------
+
+func TestNearestFloat64Fraction(t *testing.T) {
+	cases := []struct {
+		f         frac.Fraction
+		near, gap string
+	}{
+		{mustNew(t, 1, 3), "6004799503160661/18014398509481984", "1/54043195528445952"},
+		{mustNew(t, -1, 3), "-6004799503160661/18014398509481984", "-1/54043195528445952"},
+		{mustNew(t, 3, 8), "3/8", "0"},
+		{frac.Zero(), "0", "0"},
+	}
+	for _, c := range cases {
+		near, gap, err := c.f.NearestFloat64Fraction()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if near.String() != c.near || gap.String() != c.gap {
+			t.Fatalf("(%v).NearestFloat64Fraction() = %v, %v, want %s, %s", c.f, near, gap, c.near, c.gap)
+		}
+		if sum, err := frac.Add(near, gap); err != nil || !sum.Equal(c.f) {
+			t.Fatalf("%v + %v = %v, %v, want %v", near, gap, sum, err, c.f)
+		}
+	}
+
+	tiny := frac.MustNew(uint64(1), uint64(math.MaxUint64))
+	if _, _, err := tiny.NearestFloat64Fraction(); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("(%v).NearestFloat64Fraction() error = %v, want ErrOutOfRange", tiny, err)
+	}
+}
+
+// --- FixedPointError -------------------------------------------------------
+
+func TestFixedPointError(t *testing.T) {
+	cases := []struct {
+		f    frac.Fraction
+		bits uint
+		want string
+	}{
+		{mustNew(t, 1, 3), 4, "1/48"},
+		{mustNew(t, 1, 3), 8, "1/768"},
+		{mustNew(t, -1, 3), 8, "1/768"},
+		{mustNew(t, 2, 3), 4, "1/48"},
+		{mustNew(t, 3, 8), 3, "0"},
+		{mustNew(t, 3, 8), 2, "1/8"},
+		{mustNew(t, 7, 2), 0, "1/2"},
+		{frac.Zero(), 63, "0"},
+	}
+	for _, c := range cases {
+		got, err := c.f.FixedPointError(c.bits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("(%v).FixedPointError(%d) = %v, want %s", c.f, c.bits, got, c.want)
+		}
+	}
+
+	if _, err := mustNew(t, 1, 3).FixedPointError(64); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("64 bits error = %v, want ErrOutOfRange", err)
+	}
+	if _, err := frac.NewI(4).FixedPointError(63); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("overflowing scale error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// --- ToFields / FromFields -------------------------------------------------
+
+func TestToFieldsRoundTrip(t *testing.T) {
+	for _, f := range []frac.Fraction{
+		mustNew(t, 3, 4), mustNew(t, -3, 4), frac.Zero(), frac.NewI(-7),
+		frac.MustNew(int64(math.MaxInt64), uint64(math.MaxUint64)),
+	} {
+		num, den, neg, exact := f.ToFields()
+		if !exact || num < 0 || neg != f.IsNegative() {
+			t.Fatalf("(%v).ToFields() = %d, %d, %v, %v", f, num, den, neg, exact)
+		}
+		back, err := frac.FromFields(num, den, neg)
+		if err != nil || !back.Equal(f) {
+			t.Fatalf("FromFields(%d, %d, %v) = %v, %v, want %v", num, den, neg, back, err, f)
+		}
+	}
+}
+
+func TestToFields_Inexact(t *testing.T) {
+	// The numerator of (2^63 + 1)/2 doesn't fit, the closest ones that do are the integers half a unit away
+	f := frac.MustNew(uint64(1)<<63+1, uint64(2))
+	num, den, neg, exact := f.ToFields()
+	if exact || neg {
+		t.Fatalf("(%v).ToFields() exact = %v, negative = %v, want false, false", f, exact, neg)
+	}
+	approx, err := frac.FromFields(num, den, neg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff, err := frac.Subtract(approx, f); err != nil || diff.Abs().String() != "1/2" {
+		t.Fatalf("(%v).ToFields() = %d/%d, want an integer next to it", f, num, den)
+	}
+
+	huge := frac.NewI(uint64(math.MaxUint64)).Negate()
+	num, den, neg, exact = huge.ToFields()
+	if exact || num != math.MaxInt64 || den != 1 || !neg {
+		t.Fatalf("(%v).ToFields() = %d, %d, %v, %v, want MaxInt64, 1, true, false", huge, num, den, neg, exact)
+	}
+}
+
+func TestFromFields(t *testing.T) {
+	cases := []struct {
+		num  int64
+		den  uint64
+		neg  bool
+		want string
+	}{
+		{6, 8, false, "3/4"},
+		{6, 8, true, "-3/4"},
+		{-6, 8, false, "-3/4"},
+		{-6, 8, true, "3/4"},
+		{0, 5, true, "0"},
+	}
+	for _, c := range cases {
+		got, err := frac.FromFields(c.num, c.den, c.neg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("FromFields(%d, %d, %v) = %v, want %s", c.num, c.den, c.neg, got, c.want)
+		}
+	}
+	if _, err := frac.FromFields(1, 0, false); !errors.Is(err, frac.ErrZeroDenominator) {
+		t.Fatalf("zero denominator error = %v, want ErrZeroDenominator", err)
+	}
+}
+
+// --- FromDurationRatio / ScaleDuration -------------------------------------
+
+func TestFromDurationRatio(t *testing.T) {
+	got, err := frac.FromDurationRatio(30*time.Minute, 2*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "1/4" {
+		t.Fatalf("30min / 2h = %v, want 1/4", got)
+	}
+
+	got, err = frac.FromDurationRatio(-90*time.Second, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "-3/2" {
+		t.Fatalf("-90s / 1min = %v, want -3/2", got)
+	}
+
+	if _, err := frac.FromDurationRatio(time.Second, 0); !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("ratio over zero error = %v, want ErrDivideByZero", err)
+	}
+}
+
+func TestScaleDuration(t *testing.T) {
+	cases := []struct {
+		f    frac.Fraction
+		d    time.Duration
+		want time.Duration
+	}{
+		{mustNew(t, 1, 4), 2 * time.Hour, 30 * time.Minute},
+		{mustNew(t, -3, 2), time.Minute, -90 * time.Second},
+		{mustNew(t, 1, 3), 2, 1},   // 2/3 rounds to 1
+		{mustNew(t, 1, 2), 3, 2},   // 3/2 rounds away from zero
+		{mustNew(t, 1, 2), -3, -2}, // and so does -3/2
+		{frac.Zero(), time.Hour, 0},
+		// 128 bit intermediate: (MaxInt64 * 3) / 4 doesn't fit in 64 bits before dividing
+		{mustNew(t, 3, 4), math.MaxInt64, 6917529027641081855},
+	}
+	for _, c := range cases {
+		if got := c.f.ScaleDuration(c.d); got != c.want {
+			t.Fatalf("(%v).ScaleDuration(%d) = %d, want %d", c.f, c.d, got, c.want)
+		}
+	}
+}
+
+func TestScaleDuration_Saturates(t *testing.T) {
+	if got := frac.NewI(2).ScaleDuration(math.MaxInt64); got != math.MaxInt64 {
+		t.Fatalf("2 * MaxInt64 = %d, want saturation to MaxInt64", got)
+	}
+	if got := frac.NewI(-2).ScaleDuration(math.MaxInt64); got != math.MinInt64 {
+		t.Fatalf("-2 * MaxInt64 = %d, want saturation to MinInt64", got)
+	}
+}
+
+// --- Ticks / FromTicks -----------------------------------------------------
+
+func TestTicks(t *testing.T) {
+	cases := []struct {
+		f       frac.Fraction
+		tickDen uint64
+		ticks   int64
+		exact   bool
+	}{
+		{mustNew(t, 1234, 100), 100, 1234, true},
+		{mustNew(t, -3, 4), 100, -75, true},
+		{mustNew(t, 1, 3), 100, 33, false},
+		{mustNew(t, -1, 8), 100, -13, false}, // -12.5 rounds away from zero
+		{frac.NewI(7), 100, 700, true},
+		{mustNew(t, 1, 2), 0, 0, false},
+		{frac.NewI(uint64(1) << 62), 100, 0, false},
+	}
+	for _, c := range cases {
+		ticks, exact := c.f.Ticks(c.tickDen)
+		if ticks != c.ticks || exact != c.exact {
+			t.Fatalf("(%v).Ticks(%d) = %d, %v, want %d, %v", c.f, c.tickDen, ticks, exact, c.ticks, c.exact)
+		}
+	}
+}
+
+func TestFromTicks(t *testing.T) {
+	got, err := frac.FromTicks(-1250, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "-25/2" {
+		t.Fatalf("FromTicks(-1250, 100) = %v, want -25/2", got)
+	}
+	if ticks, exact := got.Ticks(100); ticks != -1250 || !exact {
+		t.Fatalf("round trip gave %d ticks (exact=%v), want -1250", ticks, exact)
+	}
+
+	if _, err := frac.FromTicks(1, 0); !errors.Is(err, frac.ErrZeroDenominator) {
+		t.Fatalf("FromTicks with tickDen 0 error = %v, want ErrZeroDenominator", err)
+	}
+}
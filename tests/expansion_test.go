@@ -0,0 +1,247 @@
+package fraction_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- DigitsInBase ----------------------------------------------------------
+
+func TestDigitsInBase(t *testing.T) {
+	cases := []struct {
+		f         frac.Fraction
+		base, max int
+		intDigits []int
+		frac      []int
+		repeat    int
+	}{
+		{mustNew(t, 1, 6), 10, 20, []int{0}, []int{1, 6}, 1},
+		{mustNew(t, 1, 2), 10, 20, []int{0}, []int{5}, -1},
+		{mustNew(t, 1, 7), 10, 20, []int{0}, []int{1, 4, 2, 8, 5, 7}, 0},
+		{mustNew(t, 1, 7), 10, 3, []int{0}, []int{1, 4, 2}, -1},
+		{mustNew(t, 1, 7), 10, 6, []int{0}, []int{1, 4, 2, 8, 5, 7}, 0},
+		{mustNew(t, -13, 4), 10, 20, []int{3}, []int{2, 5}, -1},
+		{frac.NewI(10), 2, 20, []int{1, 0, 1, 0}, nil, -1},
+		{mustNew(t, 5, 3), 2, 20, []int{1}, []int{1, 0}, 0},
+		{mustNew(t, 255, 16), 16, 20, []int{15}, []int{15}, -1},
+		{mustNew(t, 1, 3), 3, 20, []int{0}, []int{1}, -1},
+	}
+	for _, c := range cases {
+		intDigits, fracDigits, repeat, err := c.f.DigitsInBase(c.base, c.max)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(intDigits, c.intDigits) || !slices.Equal(fracDigits, c.frac) || repeat != c.repeat {
+			t.Fatalf("(%v).DigitsInBase(%d, %d) = %v, %v, %d, want %v, %v, %d",
+				c.f, c.base, c.max, intDigits, fracDigits, repeat, c.intDigits, c.frac, c.repeat)
+		}
+	}
+}
+
+func TestDigitsInBase_Invalid(t *testing.T) {
+	f := mustNew(t, 1, 3)
+	for _, base := range []int{-2, 0, 1} {
+		if _, _, _, err := f.DigitsInBase(base, 10); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("DigitsInBase(%d) error = %v, want ErrInvalid", base, err)
+		}
+	}
+	if _, _, _, err := f.DigitsInBase(10, -1); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("negative maxDigits error = %v, want ErrInvalid", err)
+	}
+}
+
+// --- DecimalParts ----------------------------------------------------------
+
+func TestDecimalParts(t *testing.T) {
+	cases := []struct {
+		f                   frac.Fraction
+		whole, term, repeat string
+	}{
+		{mustNew(t, 1, 6), "0", "1", "6"},
+		{mustNew(t, 1, 2), "0", "5", ""},
+		{mustNew(t, 1, 3), "0", "", "3"},
+		{mustNew(t, 22, 7), "3", "", "142857"},
+		{mustNew(t, -7, 4), "-1", "75", ""},
+		{mustNew(t, 5, 1), "5", "", ""},
+		{frac.Zero(), "0", "", ""},
+	}
+	for _, c := range cases {
+		whole, term, repeat, complete := c.f.DecimalParts()
+		if whole != c.whole || term != c.term || repeat != c.repeat || !complete {
+			t.Fatalf("(%v).DecimalParts() = %q, %q, %q, %v, want %q, %q, %q, true",
+				c.f, whole, term, repeat, complete, c.whole, c.term, c.repeat)
+		}
+	}
+
+	// The 1020 digit period of 1/1021 fits in the 1024 digits that are examined, the 1032 digit one of 1/1033 doesn't
+	if _, term, repeat, complete := mustNew(t, 1, 1021).DecimalParts(); !complete || term != "" || len(repeat) != 1020 {
+		t.Fatalf("(1/1021).DecimalParts() = %q, %d repeating digits, %v", term, len(repeat), complete)
+	}
+	if _, term, repeat, complete := mustNew(t, 1, 1033).DecimalParts(); complete || len(term) != 1024 || repeat != "" {
+		t.Fatalf("(1/1033).DecimalParts() = %d terminating digits, %q, %v, want 1024, \"\", false",
+			len(term), repeat, complete)
+	}
+}
+
+// --- BalancedBaseDigits ----------------------------------------------------
+
+func TestBalancedBaseDigits(t *testing.T) {
+	cases := []struct {
+		f          frac.Fraction
+		base, max  int
+		intDigits  []int
+		fracDigits []int
+	}{
+		{frac.NewI(2), 3, 10, []int{1, -1}, nil},
+		{frac.NewI(5), 3, 10, []int{1, -1, -1}, nil},
+		{frac.NewI(-5), 3, 10, []int{-1, 1, 1}, nil},
+		{frac.Zero(), 3, 10, []int{0}, nil},
+		{mustNew(t, 1, 3), 3, 10, []int{0}, []int{1}},
+		{mustNew(t, 2, 3), 3, 10, []int{1}, []int{-1}},
+		{mustNew(t, -2, 3), 3, 10, []int{-1}, []int{1}},
+		{mustNew(t, 1, 2), 3, 4, []int{0}, []int{1, 1, 1, 1}},
+		{mustNew(t, -1, 2), 3, 4, []int{0}, []int{-1, -1, -1, -1}},
+		{mustNew(t, 1, 4), 3, 4, []int{0}, []int{1, -1, 1, -1}},
+		{mustNew(t, 7, 5), 5, 10, []int{1}, []int{2}},
+		{mustNew(t, 8, 5), 5, 10, []int{2}, []int{-2}},
+		{frac.NewI(12), 5, 10, []int{2, 2}, nil},
+		{frac.NewI(13), 5, 10, []int{1, -2, -2}, nil},
+	}
+	for _, c := range cases {
+		intDigits, fracDigits, err := c.f.BalancedBaseDigits(c.base, c.max)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(intDigits, c.intDigits) || !slices.Equal(fracDigits, c.fracDigits) {
+			t.Fatalf("(%v).BalancedBaseDigits(%d, %d) = %v, %v, want %v, %v",
+				c.f, c.base, c.max, intDigits, fracDigits, c.intDigits, c.fracDigits)
+		}
+	}
+}
+
+func TestBalancedBaseDigits_ValueRoundTrip(t *testing.T) {
+	// Terminating expansions have to add up to the exact value again
+	for _, f := range []frac.Fraction{mustNew(t, 17, 9), mustNew(t, -41, 27), mustNew(t, 123, 81), frac.NewI(-100)} {
+		intDigits, fracDigits, err := f.BalancedBaseDigits(3, 20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum := frac.StartI(0)
+		for _, d := range intDigits {
+			sum = sum.Mult(frac.NewI(3)).Sum(frac.NewI(d))
+		}
+		scale := frac.StartI(1)
+		for _, d := range fracDigits {
+			scale = scale.Div(frac.NewI(3))
+			digit, err := scale.Mult(frac.NewI(d)).Result()
+			if err != nil {
+				t.Fatal(err)
+			}
+			sum = sum.Sum(digit)
+		}
+		if got, err := sum.Result(); err != nil || !got.Equal(f) {
+			t.Fatalf("(%v).BalancedBaseDigits(3, 20) = %v, %v, which adds up to %v, %v", f, intDigits, fracDigits, got, err)
+		}
+	}
+}
+
+func TestBalancedBaseDigits_Invalid(t *testing.T) {
+	f := mustNew(t, 1, 3)
+	for _, base := range []int{-3, 0, 1, 2, 4, 10} {
+		if _, _, err := f.BalancedBaseDigits(base, 10); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("BalancedBaseDigits(%d) error = %v, want ErrInvalid", base, err)
+		}
+	}
+	if _, _, err := f.BalancedBaseDigits(3, -1); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("negative maxDigits error = %v, want ErrInvalid", err)
+	}
+}
+
+// --- BaseExpansionTerms ----------------------------------------------------
+
+func TestBaseExpansionTerms(t *testing.T) {
+	cases := []struct {
+		f        frac.Fraction
+		base     int
+		maxTerms int
+		want     string
+	}{
+		{mustNew(t, 5, 4), 10, 10, "[{1 0} {2 -1} {5 -2}]"},
+		{frac.NewI(305), 10, 10, "[{3 2} {5 0}]"},
+		{mustNew(t, -5, 4), 10, 10, "[{-1 0} {-2 -1} {-5 -2}]"},
+		{mustNew(t, 1, 3), 10, 3, "[{3 -1} {3 -2} {3 -3}]"},
+		{mustNew(t, 1, 101), 10, 2, "[{9 -3} {9 -4}]"},
+		{mustNew(t, 5, 8), 2, 10, "[{1 -1} {1 -3}]"},
+		{mustNew(t, 1, 3), 3, 10, "[{1 -1}]"},
+		{frac.NewI(305), 10, 1, "[{3 2}]"},
+		{frac.Zero(), 10, 10, "[]"},
+		{mustNew(t, 5, 4), 10, 0, "[]"},
+	}
+	for _, c := range cases {
+		terms, err := c.f.BaseExpansionTerms(c.base, c.maxTerms)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprint(terms); got != c.want {
+			t.Fatalf("(%v).BaseExpansionTerms(%d, %d) = %s, want %s", c.f, c.base, c.maxTerms, got, c.want)
+		}
+	}
+}
+
+func TestBaseExpansionTerms_Invalid(t *testing.T) {
+	f := mustNew(t, 1, 3)
+	for _, base := range []int{-2, 0, 1} {
+		if _, err := f.BaseExpansionTerms(base, 10); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("BaseExpansionTerms(%d) error = %v, want ErrInvalid", base, err)
+		}
+	}
+	if _, err := f.BaseExpansionTerms(10, -1); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("negative maxTerms error = %v, want ErrInvalid", err)
+	}
+}
+
+// --- DecimalDigits ---------------------------------------------------------
+
+// takeDigits pulls at most n digits out of f.DecimalDigits(), checking the positions count up from 1
+func takeDigits(t *testing.T, f frac.Fraction, n int) []int {
+	t.Helper()
+	var digits []int
+	for pos, d := range f.DecimalDigits() {
+		if pos != len(digits)+1 {
+			t.Fatalf("(%v).DecimalDigits() yielded position %d, want %d", f, pos, len(digits)+1)
+		}
+		if len(digits) == n {
+			break
+		}
+		digits = append(digits, d)
+	}
+	return digits
+}
+
+func TestDecimalDigits(t *testing.T) {
+	cases := []struct {
+		f    frac.Fraction
+		n    int
+		want string
+	}{
+		{mustNew(t, 1, 7), 6, "[1 4 2 8 5 7]"},
+		{mustNew(t, 1, 7), 14, "[1 4 2 8 5 7 1 4 2 8 5 7 1 4]"},
+		{mustNew(t, 1, 8), 10, "[1 2 5]"},
+		{mustNew(t, -22, 7), 3, "[1 4 2]"},
+		{mustNew(t, 1, 6), 4, "[1 6 6 6]"},
+		{mustNew(t, 1, 100), 10, "[0 1]"},
+		{frac.NewI(5), 10, "[]"},
+		{frac.Zero(), 10, "[]"},
+		{mustNew(t, 1, 9223372036854775807), 20, "[0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 1 0]"},
+	}
+	for _, c := range cases {
+		got := takeDigits(t, c.f, c.n)
+		if s := fmt.Sprint(got); s != c.want {
+			t.Fatalf("first %d of (%v).DecimalDigits() = %s, want %s", c.n, c.f, s, c.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package fraction_test
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- FractionFlag ----------------------------------------------------------
+
+func TestFractionFlag(t *testing.T) {
+	ratio := frac.One()
+	scale := frac.One()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&frac.FractionFlag{Fraction: &ratio}, "ratio", "a ratio")
+	fs.Var(&frac.FractionFlag{Fraction: &scale}, "scale", "a scale")
+
+	if err := fs.Parse([]string{"-ratio", "3/4", "-scale=0.75"}); err != nil {
+		t.Fatal(err)
+	}
+	if ratio.String() != "3/4" || scale.String() != "3/4" {
+		t.Fatalf("parsed flags ratio=%v scale=%v, want 3/4 for both", ratio, scale)
+	}
+}
+
+func TestFractionFlag_Decimals(t *testing.T) {
+	cases := map[string]string{
+		"0.05":   "1/20",
+		"1.007":  "1007/1000",
+		"-0.007": "-7/1000",
+		"2.50":   "5/2",
+	}
+	for in, want := range cases {
+		var ff frac.FractionFlag
+		if err := ff.Set(in); err != nil {
+			t.Fatalf("Set(%q): %v", in, err)
+		}
+		if ff.String() != want {
+			t.Fatalf("Set(%q) stored %v, want %s", in, ff.String(), want)
+		}
+	}
+}
+
+func TestFractionFlag_Invalid(t *testing.T) {
+	ratio := frac.One()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&frac.FractionFlag{Fraction: &ratio}, "ratio", "a ratio")
+
+	for _, bad := range []string{"abc", "1/0", ""} {
+		if err := fs.Parse([]string{"-ratio", bad}); err == nil {
+			t.Fatalf("-ratio %q should fail", bad)
+		}
+	}
+	if !ratio.Equal(frac.One()) {
+		t.Fatalf("failed Set changed the value to %v", ratio)
+	}
+}
+
+func TestFractionFlag_NilFraction(t *testing.T) {
+	var ff frac.FractionFlag
+	if ff.String() != "" {
+		t.Fatalf("empty flag String() = %q, want \"\"", ff.String())
+	}
+	if err := ff.Set("-2/6"); err != nil {
+		t.Fatal(err)
+	}
+	if ff.String() != "-1/3" {
+		t.Fatalf("flag value = %v, want -1/3", ff.String())
+	}
+}
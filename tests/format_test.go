@@ -0,0 +1,161 @@
+package fraction_test
+
+import (
+	"errors"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- CompactString ---------------------------------------------------------
+
+func TestCompactString(t *testing.T) {
+	cases := map[string]frac.Fraction{
+		"2":     frac.NewI(2),
+		"-7":    frac.NewI(-7),
+		"0":     frac.Zero(),
+		"0.5":   mustNew(t, 1, 2),
+		"-0.5":  mustNew(t, -1, 2),
+		"1/3":   mustNew(t, 1, 3),
+		"1/8":   mustNew(t, 1, 8),
+		"3/4":   mustNew(t, 3, 4),
+		"-5/4":  mustNew(t, -5, 4),
+		"0.3":   mustNew(t, 3, 10),
+		"-0.03": mustNew(t, -3, 100),
+		"2.5":   mustNew(t, 5, 2),
+		"0.01":  mustNew(t, 1, 100),
+		"7/12":  mustNew(t, 7, 12),
+	}
+	for want, f := range cases {
+		if got := f.CompactString(); got != want {
+			t.Fatalf("CompactString(%v) = %q, want %q", f, got, want)
+		}
+	}
+}
+
+// --- Key -------------------------------------------------------------------
+
+func TestKey(t *testing.T) {
+	cases := map[string]frac.Fraction{
+		"+:1:2":  mustNew(t, 1, 2),
+		"-:1:2":  mustNew(t, -1, 2),
+		"+:2:1":  frac.NewI(2),
+		"+:0:1":  frac.Zero(),
+		"-:7:12": mustNew(t, 7, -12),
+		"+:9223372036854775807:9223372036854775806": mustNew(t, 9223372036854775807, 9223372036854775806),
+	}
+	for want, f := range cases {
+		if got := f.Key(); got != want {
+			t.Fatalf("(%v).Key() = %q, want %q", f, got, want)
+		}
+	}
+}
+
+func TestKey_Canonical(t *testing.T) {
+	// Equal values share a key no matter how they were built
+	same := [][2]frac.Fraction{
+		{mustNew(t, 1, 2), mustNew(t, 2, 4)},
+		{frac.NewI(2), mustNew(t, 4, 2)},
+		{frac.Zero(), mustNew(t, 0, -5)},
+		{mustNew(t, -3, 4), mustNew(t, 6, -8)},
+	}
+	for _, p := range same {
+		if p[0].Key() != p[1].Key() {
+			t.Fatalf("(%v).Key() = %q and (%v).Key() = %q, want them equal", p[0], p[0].Key(), p[1], p[1].Key())
+		}
+	}
+
+	// Distinct values never share one
+	seen := map[string]frac.Fraction{}
+	for n := int64(-12); n <= 12; n++ {
+		for d := int64(1); d <= 12; d++ {
+			f := mustNew(t, n, d)
+			if prev, ok := seen[f.Key()]; ok && !prev.Equal(f) {
+				t.Fatalf("%v and %v share the key %q", prev, f, f.Key())
+			}
+			seen[f.Key()] = f
+		}
+	}
+}
+
+func BenchmarkKey(b *testing.B) {
+	f := frac.MustNew(-355, 113)
+	for range b.N {
+		_ = f.Key()
+	}
+}
+
+// --- Render ----------------------------------------------------------------
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		f      frac.Fraction
+		format frac.Format
+		want   string
+	}{
+		{mustNew(t, 3, 2), frac.FormatImproper, "3/2"},
+		{mustNew(t, 3, 2), frac.FormatMixed, "1 1/2"},
+		{mustNew(t, -7, 3), frac.FormatMixed, "-2 1/3"},
+		{mustNew(t, 1, 3), frac.FormatMixed, "1/3"},
+		{mustNew(t, -4, 1), frac.FormatMixed, "-4"},
+		{frac.Zero(), frac.FormatMixed, "0"},
+		{mustNew(t, 3, 2), frac.FormatDecimal, "1.5"},
+		{mustNew(t, 1, 3), frac.FormatDecimal, "0.333333"},
+		{mustNew(t, -2, 3), frac.FormatDecimal, "-0.666667"},
+		{frac.NewI(5), frac.FormatDecimal, "5"},
+		{mustNew(t, 3, 2), frac.FormatPercent, "150%"},
+		{mustNew(t, 1, 3), frac.FormatPercent, "33.33%"},
+		{mustNew(t, -1, 8), frac.FormatPercent, "-12.5%"},
+		{frac.Zero(), frac.FormatPercent, "0%"},
+	}
+	for _, c := range cases {
+		got, err := c.f.Render(c.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Fatalf("(%v).Render(%d) = %q, want %q", c.f, c.format, got, c.want)
+		}
+	}
+}
+
+func TestRender_Errors(t *testing.T) {
+	for _, format := range []frac.Format{-1, 4, 100} {
+		if _, err := mustNew(t, 1, 2).Render(format); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("Render(%d) error = %v, want ErrInvalid", format, err)
+		}
+	}
+	if _, err := frac.NewI(uint64(1) << 62).Render(frac.FormatPercent); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("overflowing percent error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// --- ScientificString ------------------------------------------------------
+
+func TestScientificString(t *testing.T) {
+	cases := []struct {
+		f       frac.Fraction
+		sigFigs int
+		want    string
+	}{
+		{frac.NewI(1500), 3, "1.5e3"},
+		{frac.NewI(1500), 1, "2e3"},
+		{mustNew(t, 1, 30), 3, "3.33e-2"},
+		{mustNew(t, -1, 30), 3, "-3.33e-2"},
+		{frac.NewI(7), 4, "7e0"},
+		{mustNew(t, 1, 3), 5, "3.3333e-1"},
+		{mustNew(t, 2, 3), 2, "6.7e-1"},
+		{mustNew(t, 999, 1000), 2, "1e0"}, // rounds up into the next power of ten
+		{mustNew(t, 9995, 1), 3, "1e4"},
+		{mustNew(t, 1, 8), 2, "1.3e-1"}, // halves away from zero
+		{frac.NewI(123456), 0, "1e5"},
+		{mustNew(t, 1, 9223372036854775807), 3, "1.08e-19"},
+		{frac.NewI(uint64(18446744073709551615)), 4, "1.845e19"},
+		{frac.Zero(), 3, "0"},
+	}
+	for _, c := range cases {
+		if got := c.f.ScientificString(c.sigFigs); got != c.want {
+			t.Fatalf("(%v).ScientificString(%d) = %q, want %q", c.f, c.sigFigs, got, c.want)
+		}
+	}
+}
@@ -1,7 +1,8 @@
 package fraction_test
 
 import (
-	"fmt"
+	"errors"
+	"math"
 	"testing"
 
 	frac "github.com/sea2horses/go-betterfractions"
@@ -182,19 +183,22 @@ func TestNegateAndAbs(t *testing.T) {
 
 func TestParseDecimal(t *testing.T) {
 	cases := map[string]frac.Fraction{
-		"-0.3": mustNew(t, 3, 10),
-		"0.2":  mustNew(t, 2, 10),
-		"0.5":  mustNew(t, 1, 2),
-		"2.5":  mustNew(t, 5, 2),
+		"-0.3":   mustNew(t, -3, 10),
+		"0.2":    mustNew(t, 2, 10),
+		"0.5":    mustNew(t, 1, 2),
+		"2.5":    mustNew(t, 5, 2),
+		"0.05":   mustNew(t, 1, 20),
+		"-0.007": mustNew(t, -7, 1000),
+		"-0":     frac.Zero(),
+		"-7":     frac.NewI(-7),
 	}
 
 	for k, want := range cases {
-		fmt.Printf("t: %v\n", t)
 		conv, err := frac.ParseDecimal(k)
 		if err != nil {
 			t.Fatalf("%s was not able to be converted into fraction, error: %v", k, err)
 		}
-		if !conv.Equal(want) {
+		if !conv.Equal(want) || conv.IsNegative() != want.IsNegative() {
 			t.Fatalf("String %s was incorrectly converted into %s", k, conv)
 		}
 	}
@@ -321,4 +325,330 @@ func TestChain_WithInvertNegateAbs(t *testing.T) {
     if res.String() != "2" {
         t.Fatalf("chain result = %v, want 2", res)
     }
-}
\ No newline at end of file
+}
+
+// --- DisplayWidth ----------------------------------------------------------
+
+func TestDisplayWidth(t *testing.T) {
+	cases := []frac.Fraction{
+		frac.Zero(),
+		frac.NewI(7),
+		frac.NewI(-42),
+		mustNew(t, 3, 4),
+		mustNew(t, -10, 7),
+		frac.NewI(uint64(18446744073709551615)),
+		mustNew(t, -9223372036854775807, 1000003),
+	}
+	for _, f := range cases {
+		if got, want := f.DisplayWidth(), len([]rune(f.String())); got != want {
+			t.Fatalf("DisplayWidth(%v) = %d, want %d", f, got, want)
+		}
+	}
+}
+
+// --- WillAddOverflow / WillMultiplyOverflow --------------------------------
+
+func TestWillOverflow_MatchesOperations(t *testing.T) {
+	max := frac.NewI(uint64(18446744073709551615))
+	big := frac.NewI(uint64(1) << 40)
+	cases := [][2]frac.Fraction{
+		{mustNew(t, 1, 3), mustNew(t, 1, 6)},
+		{max, frac.NewI(1)},
+		{max, frac.NewI(-1)},
+		{big, big},
+		{mustNew(t, 1, 4294967311), mustNew(t, 1, 4294967357)},
+		{mustNew(t, 1, 2), max},
+		{frac.Zero(), max},
+		{mustNew(t, -7, 3), mustNew(t, 3, 7)},
+	}
+	for _, c := range cases {
+		_, err := frac.Add(c[0], c[1])
+		if got := frac.WillAddOverflow(c[0], c[1]); got != (err != nil) {
+			t.Fatalf("WillAddOverflow(%v, %v) = %v, but Add error = %v", c[0], c[1], got, err)
+		}
+		_, err = frac.Multiply(c[0], c[1])
+		if got := frac.WillMultiplyOverflow(c[0], c[1]); got != (err != nil) {
+			t.Fatalf("WillMultiplyOverflow(%v, %v) = %v, but Multiply error = %v", c[0], c[1], got, err)
+		}
+	}
+}
+
+func TestWillOverflow_NoAllocs(t *testing.T) {
+	a := mustNew(t, 1, 4294967311)
+	b := mustNew(t, 1, 4294967357)
+	allocs := testing.AllocsPerRun(100, func() {
+		frac.WillAddOverflow(a, b)
+		frac.WillMultiplyOverflow(a, b)
+	})
+	if allocs != 0 {
+		t.Fatalf("overflow checks allocated %v times, want 0", allocs)
+	}
+}
+
+// --- free comparators ------------------------------------------------------
+
+func TestFreeComparators_MatchMethods(t *testing.T) {
+	values := []frac.Fraction{
+		mustNew(t, -3, 2), mustNew(t, -1, 3), frac.Zero(),
+		mustNew(t, 1, 3), mustNew(t, 2, 6), mustNew(t, 5, 4),
+	}
+	for _, a := range values {
+		for _, b := range values {
+			if frac.Less(a, b) != a.Less(b) ||
+				frac.LessEq(a, b) != a.LessEq(b) ||
+				frac.Greater(a, b) != a.Greater(b) ||
+				frac.GreaterEq(a, b) != a.GreaterEq(b) ||
+				frac.NotEqual(a, b) != !a.Equal(b) {
+				t.Fatalf("free comparators disagree with methods for %v, %v", a, b)
+			}
+		}
+	}
+}
+
+// --- OrderOfMagnitude ------------------------------------------------------
+
+func TestOrderOfMagnitude(t *testing.T) {
+	cases := []struct {
+		f    frac.Fraction
+		want int
+	}{
+		{mustNew(t, 1, 1000), -3},
+		{frac.NewI(5), 0},
+		{frac.NewI(150), 2},
+		{frac.NewI(-150), 2},
+		{frac.NewI(100), 2},
+		{frac.NewI(99), 1},
+		{mustNew(t, 1, 10), -1},
+		{mustNew(t, 99, 1000), -2},
+		{mustNew(t, 999, 1000), -1},
+		{mustNew(t, 1001, 1000), 0},
+		{mustNew(t, 10, 3), 0},
+		{mustNew(t, 1, 3), -1},
+		{frac.NewI(uint64(18446744073709551615)), 19},
+		{frac.MustNew(1, uint64(18446744073709551615)), -20},
+		{frac.MustNew(uint64(9999999999999999999), 7), 18},
+	}
+	for _, c := range cases {
+		if got := c.f.OrderOfMagnitude(); got != c.want {
+			t.Fatalf("OrderOfMagnitude(%v) = %d, want %d", c.f, got, c.want)
+		}
+	}
+	if got := frac.Zero().OrderOfMagnitude(); got != math.MinInt {
+		t.Fatalf("OrderOfMagnitude(0) = %d, want math.MinInt", got)
+	}
+}
+
+// --- Unicode minus signs ---------------------------------------------------
+
+func TestParse_UnicodeMinus(t *testing.T) {
+	for _, sign := range []string{"−", "–"} {
+		fr, err := frac.ParseFracString(sign + "3/4")
+		if err != nil {
+			t.Fatalf("ParseFracString(%q): %v", sign+"3/4", err)
+		}
+		if fr.String() != "-3/4" {
+			t.Fatalf("ParseFracString(%q) = %v, want -3/4", sign+"3/4", fr)
+		}
+
+		fr, err = frac.ParseDecimal(" " + sign + "1.5")
+		if err != nil {
+			t.Fatalf("ParseDecimal(%q): %v", sign+"1.5", err)
+		}
+		if fr.String() != "-3/2" {
+			t.Fatalf("ParseDecimal(%q) = %v, want -3/2", sign+"1.5", fr)
+		}
+
+		// Dashes outside of the sign position are still rejected
+		for _, bad := range []string{"3" + sign + "4", "3/" + sign + "4", sign + sign + "3/4"} {
+			if _, err := frac.ParseFracString(bad); err == nil {
+				t.Fatalf("ParseFracString(%q) should fail", bad)
+			}
+		}
+	}
+}
+
+// --- AddInt / SubInt -------------------------------------------------------
+
+func TestAddIntSubInt(t *testing.T) {
+	cases := []struct {
+		f        frac.Fraction
+		k        int64
+		add, sub string
+	}{
+		{mustNew(t, 1, 3), 1, "4/3", "-2/3"},
+		{mustNew(t, -1, 3), 1, "2/3", "-4/3"},
+		{mustNew(t, 5, 2), -2, "1/2", "9/2"},
+		{frac.NewI(3), 3, "6", "0"},
+		{frac.NewI(-3), -3, "-6", "0"},
+		{frac.Zero(), -7, "-7", "7"},
+	}
+	for _, c := range cases {
+		add, err := c.f.AddInt(c.k)
+		if err != nil {
+			t.Fatalf("(%v).AddInt(%d): %v", c.f, c.k, err)
+		}
+		sub, err := c.f.SubInt(c.k)
+		if err != nil {
+			t.Fatalf("(%v).SubInt(%d): %v", c.f, c.k, err)
+		}
+		if add.String() != c.add || sub.String() != c.sub {
+			t.Fatalf("(%v) +/- %d = %v, %v, want %s, %s", c.f, c.k, add, sub, c.add, c.sub)
+		}
+		if add.IsNegative() && add.Equal(frac.Zero()) || sub.IsNegative() && sub.Equal(frac.Zero()) {
+			t.Fatalf("(%v) +/- %d returned a negative zero", c.f, c.k)
+		}
+	}
+}
+
+func TestAddIntSubInt_OverflowBoundary(t *testing.T) {
+	// 1/2 + MinInt64 = -(2^64 - 1)/2 still fits
+	got, err := mustNew(t, 1, 2).AddInt(math.MinInt64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "-18446744073709551615/2" {
+		t.Fatalf("1/2 + MinInt64 = %v, want -18446744073709551615/2", got)
+	}
+
+	// 1/2 - MinInt64 = (2^64 + 1)/2 doesn't
+	if _, err := mustNew(t, 1, 2).SubInt(math.MinInt64); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("1/2 - MinInt64 error = %v, want ErrOutOfRange", err)
+	}
+
+	max := frac.NewI(uint64(18446744073709551615))
+	if _, err := max.AddInt(1); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("MaxUint64 + 1 error = %v, want ErrOutOfRange", err)
+	}
+	got, err = max.SubInt(1)
+	if err != nil || got.String() != "18446744073709551614" {
+		t.Fatalf("MaxUint64 - 1 = %v, %v, want 18446744073709551614", got, err)
+	}
+
+	// k * denominator itself overflows
+	if _, err := mustNew(t, 1, 4294967311).AddInt(math.MaxInt64); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("overflowing k*d error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// --- Inverses --------------------------------------------------------------
+
+func TestInverses(t *testing.T) {
+	add, mul, err := mustNew(t, -2, 3).Inverses()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !add.Equal(mustNew(t, 2, 3)) || !mul.Equal(mustNew(t, -3, 2)) {
+		t.Fatalf("(-2/3).Inverses() = %v, %v, want 2/3, -3/2", add, mul)
+	}
+
+	add, _, err = frac.Zero().Inverses()
+	if !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("(0).Inverses() error = %v, want ErrDivideByZero", err)
+	}
+	if !add.Equal(frac.Zero()) {
+		t.Fatalf("(0).Inverses() additive = %v, want 0", add)
+	}
+}
+
+// --- FromFloat64Approx negative zero ---------------------------------------
+
+func TestFromFloat64Approx_NegativeCollapsesToZero(t *testing.T) {
+	for _, x := range []float64{-1e-30, -math.SmallestNonzeroFloat64, math.Copysign(0, -1)} {
+		got, err := frac.FromFloat64Approx(x, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != frac.Zero() || got.IsNegative() || frac.Cmp(got, frac.Zero()) != 0 {
+			t.Fatalf("FromFloat64Approx(%g, 10) = %#v, want canonical 0", x, got)
+		}
+	}
+}
+
+// --- ParseFracString sign and whitespace -----------------------------------
+
+func TestParseFracString_SignWhitespace(t *testing.T) {
+	valid := map[string]string{
+		"-3/4":       "-3/4",
+		"- 3/4":      "-3/4",
+		"-3 / 4":     "-3/4",
+		" - 3 / 4 ":  "-3/4",
+		"\t-\t3/4\n": "-3/4",
+		"− 3 / 4":    "-3/4",
+		"  3 /4":     "3/4",
+		"- 0/5":      "0",
+		"- 7":        "-7",
+		" 6 / 8 ":    "3/4",
+	}
+	for in, want := range valid {
+		got, err := frac.ParseFracString(in)
+		if err != nil {
+			t.Fatalf("ParseFracString(%q) error = %v", in, err)
+		}
+		if got.String() != want {
+			t.Fatalf("ParseFracString(%q) = %v, want %s", in, got, want)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"   ",
+		"-",
+		" - ",
+		"--3/4",
+		"- -3/4",
+		"3/-4",
+		"3 - /4",
+		"3-/4",
+		"- 3 4/5",
+		"3/4/5",
+		"+-3/4",
+		"3/",
+		"/4",
+	}
+	for _, in := range invalid {
+		if got, err := frac.ParseFracString(in); err == nil {
+			t.Fatalf("ParseFracString(%q) = %v, want an error", in, got)
+		}
+	}
+}
+
+// --- Leading zeros ---------------------------------------------------------
+
+func TestParse_LeadingZerosAreDecimal(t *testing.T) {
+	cases := map[string]string{
+		"007/009": "7/9",
+		"0010":    "10",
+		"1/007":   "1/7",
+		"/007":    "",
+		"010/08":  "5/4",
+		"-0009/1": "-9",
+		"0x10":    "",
+		"0o10":    "",
+		"0b10/1":  "",
+		"1_000/1": "",
+	}
+	for in, want := range cases {
+		got, err := frac.Parse(in)
+		if want == "" {
+			if err == nil {
+				t.Fatalf("Parse(%q) = %v, want an error", in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", in, err)
+		}
+		if got.String() != want {
+			t.Fatalf("Parse(%q) = %v, want %s", in, got, want)
+		}
+	}
+
+	got, err := frac.FromDecimalStrings("007", "009")
+	if err != nil || got.String() != "7/9" {
+		t.Fatalf("FromDecimalStrings(\"007\", \"009\") = %v, %v, want 7/9", got, err)
+	}
+	got, _, err = frac.ParsePrefix("010/08")
+	if err != nil || got.String() != "5/4" {
+		t.Fatalf("ParsePrefix(\"010/08\") = %v, %v, want 5/4", got, err)
+	}
+}
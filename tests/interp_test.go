@@ -0,0 +1,274 @@
+package fraction_test
+
+import (
+	"errors"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- Slope / InterpolateLinear ---------------------------------------------
+
+func TestSlope(t *testing.T) {
+	// y = 2x
+	m, err := frac.Slope(mustNew(t, 1, 3), mustNew(t, 2, 3), mustNew(t, 5, 4), mustNew(t, 5, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.String() != "2" {
+		t.Fatalf("slope of y=2x = %v, want 2", m)
+	}
+
+	m, err = frac.Slope(frac.Zero(), frac.NewI(1), frac.NewI(3), frac.Zero())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.String() != "-1/3" {
+		t.Fatalf("slope = %v, want -1/3", m)
+	}
+}
+
+func TestSlope_Vertical(t *testing.T) {
+	x := mustNew(t, 1, 2)
+	if _, err := frac.Slope(x, frac.Zero(), x, frac.NewI(1)); !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("vertical slope error = %v, want ErrDivideByZero", err)
+	}
+}
+
+func TestInterpolateLinear(t *testing.T) {
+	// y = 2x through (0, 0) and (1, 2)
+	x1, y1 := frac.Zero(), frac.Zero()
+	x2, y2 := frac.NewI(1), frac.NewI(2)
+	cases := map[string]frac.Fraction{
+		"2/3":  mustNew(t, 1, 3),
+		"1":    mustNew(t, 1, 2),
+		"6":    frac.NewI(3),      // extrapolated
+		"-1/2": mustNew(t, -1, 4), // extrapolated
+	}
+	for want, x := range cases {
+		got, err := frac.InterpolateLinear(x, x1, y1, x2, y2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != want {
+			t.Fatalf("InterpolateLinear(%v) on y=2x = %v, want %s", x, got, want)
+		}
+	}
+}
+
+// --- InverseLerp -----------------------------------------------------------
+
+func TestInverseLerp(t *testing.T) {
+	cases := []struct {
+		value, lo, hi frac.Fraction
+		want          string
+	}{
+		{mustNew(t, 3, 4), mustNew(t, 1, 2), frac.One(), "1/2"},
+		{mustNew(t, 1, 2), mustNew(t, 1, 2), frac.One(), "0"},
+		{frac.One(), mustNew(t, 1, 2), frac.One(), "1"},
+		{mustNew(t, 3, 2), mustNew(t, 1, 2), frac.One(), "2"},
+		{frac.Zero(), mustNew(t, 1, 2), frac.One(), "-1"},
+		{mustNew(t, 1, 3), frac.One(), frac.Zero(), "2/3"},
+	}
+	for _, c := range cases {
+		got, err := frac.InverseLerp(c.value, c.lo, c.hi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("InverseLerp(%v, %v, %v) = %v, want %s", c.value, c.lo, c.hi, got, c.want)
+		}
+	}
+
+	if _, err := frac.InverseLerp(frac.One(), mustNew(t, 1, 2), mustNew(t, 1, 2)); !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("empty range error = %v, want ErrDivideByZero", err)
+	}
+}
+
+// --- Midpoint --------------------------------------------------------------
+
+func TestMidpoint(t *testing.T) {
+	cases := []struct {
+		a, b frac.Fraction
+		want string
+	}{
+		{mustNew(t, 1, 3), mustNew(t, 1, 2), "5/12"},
+		{mustNew(t, 1, 2), mustNew(t, 1, 3), "5/12"},
+		{mustNew(t, -1, 2), mustNew(t, 1, 2), "0"},
+		{frac.Zero(), frac.One(), "1/2"},
+		{mustNew(t, 3, 4), mustNew(t, 3, 4), "3/4"},
+		{frac.NewI(-3), frac.NewI(-6), "-9/2"},
+	}
+	for _, c := range cases {
+		got, err := frac.Midpoint(c.a, c.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("Midpoint(%v, %v) = %v, want %s", c.a, c.b, got, c.want)
+		}
+	}
+
+	huge := frac.MustNew(uint64(1), uint64(1)<<63+1)
+	if _, err := frac.Midpoint(huge, frac.Zero()); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("Midpoint(%v, 0) error = %v, want ErrOutOfRange", huge, err)
+	}
+}
+
+// --- Bisect ----------------------------------------------------------------
+
+func TestBisect(t *testing.T) {
+	// x^2 - 2, the root is sqrt(2) = 1.41421356...
+	sq2 := func(x frac.Fraction) (frac.Fraction, error) {
+		return frac.Start(x).Mult(x).Sub(frac.NewI(2)).Result()
+	}
+	got, err := frac.Bisect(sq2, frac.One(), frac.NewI(2), 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := got.Float64() - 1.4142135623730951; diff > 1e-6 || diff < -1e-6 {
+		t.Fatalf("Bisect(x^2 - 2, 1, 2, 20) = %v (%g), too far from sqrt(2)", got, got.Float64())
+	}
+
+	// Works with the interval in either orientation
+	got, err = frac.Bisect(sq2, frac.NewI(2), frac.One(), 0)
+	if err != nil || got.String() != "3/2" {
+		t.Fatalf("Bisect(x^2 - 2, 2, 1, 0) = %v, %v, want 3/2", got, err)
+	}
+
+	// 3x - 1 has its root at 1/3, which the halving of [0, 1] never lands on exactly
+	third := func(x frac.Fraction) (frac.Fraction, error) {
+		return frac.Start(x).Mult(frac.NewI(3)).Sub(frac.One()).Result()
+	}
+	got, err = frac.Bisect(third, frac.Zero(), frac.One(), 3)
+	if err != nil || got.String() != "5/16" {
+		t.Fatalf("Bisect(3x - 1, 0, 1, 3) = %v, %v, want 5/16", got, err)
+	}
+}
+
+func TestBisect_ExactRoot(t *testing.T) {
+	calls := 0
+	half := func(x frac.Fraction) (frac.Fraction, error) {
+		calls++
+		return frac.Subtract(x, mustNew(t, 1, 2))
+	}
+	got, err := frac.Bisect(half, frac.Zero(), frac.One(), 50)
+	if err != nil || got.String() != "1/2" {
+		t.Fatalf("Bisect(x - 1/2, 0, 1, 50) = %v, %v, want 1/2", got, err)
+	}
+	if calls != 3 {
+		t.Fatalf("f was called %d times, want 3", calls)
+	}
+
+	got, err = frac.Bisect(half, mustNew(t, 1, 2), frac.One(), 5)
+	if err != nil || got.String() != "1/2" {
+		t.Fatalf("Bisect with root at lo = %v, %v, want 1/2", got, err)
+	}
+}
+
+func TestBisect_Errors(t *testing.T) {
+	id := func(x frac.Fraction) (frac.Fraction, error) { return x, nil }
+	if _, err := frac.Bisect(id, frac.One(), frac.NewI(2), 10); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("same sign error = %v, want ErrInvalid", err)
+	}
+	if _, err := frac.Bisect(id, frac.NewI(-1), frac.One(), -1); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("negative iterations error = %v, want ErrInvalid", err)
+	}
+
+	boom := errors.New("boom")
+	failing := func(x frac.Fraction) (frac.Fraction, error) {
+		if x.IsNegative() || x.Equal(frac.One()) {
+			return x, nil
+		}
+		return frac.Zero(), boom
+	}
+	if _, err := frac.Bisect(failing, frac.NewI(-1), frac.One(), 10); !errors.Is(err, boom) {
+		t.Fatalf("f error = %v, want %v", err, boom)
+	}
+}
+
+// --- MapToRange ------------------------------------------------------------
+
+func TestMapToRange(t *testing.T) {
+	cases := []struct {
+		f                          frac.Fraction
+		srcLo, srcHi, dstLo, dstHi frac.Fraction
+		want                       string
+	}{
+		{mustNew(t, 1, 2), frac.Zero(), frac.One(), frac.Zero(), frac.NewI(100), "50"},
+		{mustNew(t, 1, 3), frac.Zero(), frac.One(), frac.Zero(), frac.NewI(100), "100/3"},
+		{frac.NewI(2), frac.NewI(1), frac.NewI(3), frac.NewI(10), frac.NewI(20), "15"},
+		// Reversed destination, like screen coordinates growing downwards
+		{mustNew(t, 1, 4), frac.Zero(), frac.One(), frac.NewI(480), frac.Zero(), "360"},
+		// Outside the source range
+		{mustNew(t, 3, 2), frac.Zero(), frac.One(), frac.Zero(), frac.NewI(10), "15"},
+		{mustNew(t, -1, 2), frac.Zero(), frac.One(), frac.NewI(-1), frac.One(), "-2"},
+	}
+	for _, c := range cases {
+		got, err := c.f.MapToRange(c.srcLo, c.srcHi, c.dstLo, c.dstHi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("(%v).MapToRange(%v, %v, %v, %v) = %v, want %s",
+				c.f, c.srcLo, c.srcHi, c.dstLo, c.dstHi, got, c.want)
+		}
+	}
+
+	if _, err := frac.One().MapToRange(frac.One(), frac.One(), frac.Zero(), frac.One()); !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("empty source range error = %v, want ErrDivideByZero", err)
+	}
+}
+
+// --- PiecewiseLinear -------------------------------------------------------
+
+func TestPiecewiseLinear(t *testing.T) {
+	// (0, 0) -> (1, 2) -> (3, 3)
+	xs := []frac.Fraction{frac.Zero(), frac.One(), frac.NewI(3)}
+	ys := []frac.Fraction{frac.Zero(), frac.NewI(2), frac.NewI(3)}
+
+	cases := []struct {
+		x    frac.Fraction
+		want string
+	}{
+		{frac.Zero(), "0"},
+		{mustNew(t, 1, 2), "1"},
+		{mustNew(t, 1, 3), "2/3"},
+		{frac.One(), "2"},
+		{frac.NewI(2), "5/2"},
+		{mustNew(t, 7, 3), "8/3"},
+		{frac.NewI(3), "3"},
+		{frac.NewI(-5), "0"}, // clamped below
+		{frac.NewI(10), "3"}, // clamped above
+	}
+	for _, c := range cases {
+		got, err := frac.PiecewiseLinear(xs, ys, c.x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("PiecewiseLinear(%v, %v, %v) = %v, want %s", xs, ys, c.x, got, c.want)
+		}
+	}
+
+	// A single breakpoint is a constant
+	single := []frac.Fraction{mustNew(t, 1, 2)}
+	if got, err := frac.PiecewiseLinear(single, []frac.Fraction{frac.NewI(7)}, frac.NewI(-1)); err != nil || got.String() != "7" {
+		t.Fatalf("single breakpoint = %v, %v, want 7", got, err)
+	}
+}
+
+func TestPiecewiseLinear_Invalid(t *testing.T) {
+	x := mustNew(t, 1, 2)
+	cases := []struct{ xs, ys []frac.Fraction }{
+		{nil, nil},
+		{[]frac.Fraction{frac.Zero(), frac.One()}, []frac.Fraction{frac.Zero()}},
+		{[]frac.Fraction{frac.One(), frac.Zero()}, []frac.Fraction{frac.Zero(), frac.One()}},
+		{[]frac.Fraction{frac.One(), frac.One()}, []frac.Fraction{frac.Zero(), frac.One()}},
+	}
+	for _, c := range cases {
+		if _, err := frac.PiecewiseLinear(c.xs, c.ys, x); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("PiecewiseLinear(%v, %v) error = %v, want ErrInvalid", c.xs, c.ys, err)
+		}
+	}
+}
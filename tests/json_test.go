@@ -0,0 +1,81 @@
+package fraction_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- MarshalSlice / UnmarshalSlice -----------------------------------------
+
+func TestMarshalSlice(t *testing.T) {
+	cases := []struct {
+		fs   []frac.Fraction
+		want string
+	}{
+		{[]frac.Fraction{mustNew(t, 1, 2), frac.NewI(3), mustNew(t, -6, 8)}, `["1/2","3","-3/4"]`},
+		{[]frac.Fraction{}, `[]`},
+		{nil, `[]`},
+	}
+	for _, c := range cases {
+		got, err := frac.MarshalSlice(c.fs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != c.want {
+			t.Fatalf("MarshalSlice(%v) = %s, want %s", c.fs, got, c.want)
+		}
+
+		back, err := frac.UnmarshalSlice(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(back) != len(c.fs) || frac.CompareSlices(back, c.fs) != 0 {
+			t.Fatalf("UnmarshalSlice(%s) = %v, want %v", got, back, c.fs)
+		}
+	}
+}
+
+func TestUnmarshalSlice(t *testing.T) {
+	got, err := frac.UnmarshalSlice([]byte(` [ "6/8", " - 1 / 3 ", "0" ] `))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(got) != "[3/4 -1/3 0]" {
+		t.Fatalf("UnmarshalSlice = %v, want [3/4 -1/3 0]", got)
+	}
+}
+
+func TestUnmarshalSlice_Errors(t *testing.T) {
+	cases := []struct {
+		data   string
+		index  string
+		target error
+	}{
+		{`["1/2", "1/0"]`, "element 1 ", frac.ErrZeroDenominator},
+		{`["1/2", "3/4", 5]`, "element 2 ", frac.ErrInvalid},
+		{`[null]`, "element 0 ", frac.ErrInvalid},
+		{`["x"]`, "element 0 ", nil},
+	}
+	for _, c := range cases {
+		_, err := frac.UnmarshalSlice([]byte(c.data))
+		if err == nil {
+			t.Fatalf("UnmarshalSlice(%s) didn't fail", c.data)
+		}
+		if !strings.HasPrefix(err.Error(), c.index) {
+			t.Fatalf("UnmarshalSlice(%s) error = %q, want it to start with %q", c.data, err, c.index)
+		}
+		if c.target != nil && !errors.Is(err, c.target) {
+			t.Fatalf("UnmarshalSlice(%s) error = %v, want %v", c.data, err, c.target)
+		}
+	}
+
+	for _, data := range []string{`"1/2"`, `{"a": "1/2"}`, `[`} {
+		if _, err := frac.UnmarshalSlice([]byte(data)); err == nil {
+			t.Fatalf("UnmarshalSlice(%s) didn't fail", data)
+		}
+	}
+}
@@ -0,0 +1,402 @@
+package fraction_test
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- TwoUnitFractions ------------------------------------------------------
+
+func TestTwoUnitFractions(t *testing.T) {
+	a, b, ok := mustNew(t, 2, 3).TwoUnitFractions()
+	if !ok || a != 2 || b != 6 {
+		t.Fatalf("2/3 = 1/%d + 1/%d (ok=%v), want 1/2 + 1/6", a, b, ok)
+	}
+
+	cases := []frac.Fraction{
+		mustNew(t, 1, 7), mustNew(t, 5, 6), mustNew(t, 3, 4),
+		mustNew(t, 7, 12), frac.NewI(1), frac.NewI(2),
+	}
+	for _, f := range cases {
+		a, b, ok := f.TwoUnitFractions()
+		if !ok {
+			t.Fatalf("%v should have a two unit fraction decomposition", f)
+		}
+		sum, err := frac.Add(mustNew(t, 1, int64(a)), mustNew(t, 1, int64(b)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sum.Equal(f) || a > b {
+			t.Fatalf("%v: got 1/%d + 1/%d = %v", f, a, b, sum)
+		}
+	}
+}
+
+func TestTwoUnitFractions_None(t *testing.T) {
+	cases := []frac.Fraction{
+		mustNew(t, 4, 5), // no two unit fractions sum to 4/5
+		frac.NewI(3),
+		frac.Zero(),
+		mustNew(t, -1, 2),
+	}
+	for _, f := range cases {
+		if a, b, ok := f.TwoUnitFractions(); ok {
+			t.Fatalf("%v should have no decomposition, got 1/%d + 1/%d", f, a, b)
+		}
+	}
+}
+
+func TestTwoUnitFractions_LargeDenominators(t *testing.T) {
+	// Finding the decompositions means factoring the denominator, and the intermediate sums pass 2^64
+	cases := []frac.Fraction{
+		frac.MustNew(2, uint64(18446744073709551557)),  // largest prime below 2^64, only 1/d + 1/d fits
+		frac.MustNew(2, uint64(4294967291*4294967279)), // two primes just below 2^32
+		frac.MustNew(2, uint64(4294967291*4294967291)),
+		frac.MustNew(uint64(4294967279+4294967291), uint64(4294967279*4294967291)),
+	}
+	for _, f := range cases {
+		a, b, ok := f.TwoUnitFractions()
+		// 1/a + 1/b = n/d when n*a*b = d*(a+b), which needs more than 64 bits here
+		bigA, bigB := new(big.Int).SetUint64(a), new(big.Int).SetUint64(b)
+		lhs := new(big.Int).Mul(new(big.Int).SetUint64(f.Numerator()), new(big.Int).Mul(bigA, bigB))
+		rhs := new(big.Int).Mul(new(big.Int).SetUint64(f.Denominator()), new(big.Int).Add(bigA, bigB))
+		if !ok || a > b || lhs.Cmp(rhs) != 0 {
+			t.Fatalf("%v = 1/%d + 1/%d (ok=%v)", f, a, b, ok)
+		}
+	}
+
+	a, b, ok := cases[0].TwoUnitFractions()
+	if !ok || a != 18446744073709551557 || b != 18446744073709551557 {
+		t.Fatalf("%v = 1/%d + 1/%d (ok=%v), want 1/18446744073709551557 + 1/18446744073709551557", cases[0], a, b, ok)
+	}
+}
+
+// --- IsMultipleOf ----------------------------------------------------------
+
+func TestIsMultipleOf(t *testing.T) {
+	cases := []struct {
+		f, g frac.Fraction
+		want bool
+	}{
+		{mustNew(t, 3, 4), mustNew(t, 1, 4), true},
+		{mustNew(t, 3, 4), mustNew(t, 1, 2), false},
+		{mustNew(t, 3, 4), mustNew(t, 3, 4), true},
+		{mustNew(t, -3, 2), mustNew(t, 1, 2), true},
+		{frac.NewI(6), mustNew(t, -3, 2), true},
+		{mustNew(t, 1, 4), mustNew(t, 3, 4), false},
+		{frac.Zero(), mustNew(t, 5, 7), true},
+		{mustNew(t, 5, 6), mustNew(t, 1, 3), false},
+	}
+	for _, c := range cases {
+		got, err := c.f.IsMultipleOf(c.g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Fatalf("(%v).IsMultipleOf(%v) = %v, want %v", c.f, c.g, got, c.want)
+		}
+	}
+}
+
+func TestIsMultipleOf_Zero(t *testing.T) {
+	if _, err := mustNew(t, 1, 2).IsMultipleOf(frac.Zero()); !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("IsMultipleOf(0) error = %v, want ErrDivideByZero", err)
+	}
+}
+
+// --- DecimalExponent -------------------------------------------------------
+
+func TestDecimalExponent(t *testing.T) {
+	cases := []struct {
+		f    frac.Fraction
+		k    int
+		want bool
+	}{
+		{frac.NewI(5), 0, true},
+		{mustNew(t, 1, 2), 1, true},
+		{mustNew(t, 3, 8), 3, true},
+		{mustNew(t, -7, 20), 2, true},
+		{mustNew(t, 1, 3), 0, false},
+		{mustNew(t, 1, 12), 0, false},
+	}
+	for _, c := range cases {
+		k, ok := c.f.DecimalExponent()
+		if k != c.k || ok != c.want {
+			t.Fatalf("DecimalExponent(%v) = %d, %v, want %d, %v", c.f, k, ok, c.k, c.want)
+		}
+	}
+}
+
+// --- ScaleToDenominator ----------------------------------------------------
+
+func TestScaleToDenominator(t *testing.T) {
+	cases := []struct {
+		f      frac.Fraction
+		target uint64
+		factor uint64
+	}{
+		{mustNew(t, 1, 6), 4, 2},   // 2/12
+		{mustNew(t, 3, 4), 8, 2},   // 6/8
+		{mustNew(t, -2, 3), 3, 1},  // -2/3
+		{frac.NewI(5), 12, 12},     // 60/12
+		{mustNew(t, 5, 12), 18, 3}, // 15/36
+	}
+	for _, c := range cases {
+		got, factor, err := c.f.ScaleToDenominator(c.target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(c.f) || factor != c.factor {
+			t.Fatalf("(%v).ScaleToDenominator(%d) = %v, %d, want %v, %d", c.f, c.target, got, factor, c.f, c.factor)
+		}
+		if (c.f.Denominator()*factor)%c.target != 0 {
+			t.Fatalf("(%v).ScaleToDenominator(%d): scaled denominator is not a multiple of the target", c.f, c.target)
+		}
+	}
+}
+
+func TestScaleToDenominator_Errors(t *testing.T) {
+	if _, _, err := mustNew(t, 1, 2).ScaleToDenominator(0); !errors.Is(err, frac.ErrZeroDenominator) {
+		t.Fatalf("target 0 error = %v, want ErrZeroDenominator", err)
+	}
+	if _, _, err := mustNew(t, 1, 4294967311).ScaleToDenominator(4294967357); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("overflowing lcm error = %v, want ErrOutOfRange", err)
+	}
+	if _, _, err := frac.NewI(uint64(1) << 40).ScaleToDenominator(uint64(1) << 30); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("overflowing numerator error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// --- IntegerMultiplier / IntegerMultiplierOf -------------------------------
+
+func TestIntegerMultiplier(t *testing.T) {
+	cases := map[uint64]frac.Fraction{
+		4: mustNew(t, 3, 4),
+		3: mustNew(t, -2, 6),
+		1: frac.NewI(7),
+	}
+	for want, f := range cases {
+		if got := f.IntegerMultiplier(); got != want {
+			t.Fatalf("(%v).IntegerMultiplier() = %d, want %d", f, got, want)
+		}
+	}
+}
+
+func TestIntegerMultiplierOf(t *testing.T) {
+	got, err := frac.IntegerMultiplierOf(mustNew(t, 1, 4), mustNew(t, 2, 3), mustNew(t, -5, 6), frac.NewI(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 12 {
+		t.Fatalf("IntegerMultiplierOf(1/4, 2/3, -5/6, 2) = %d, want 12", got)
+	}
+
+	if got, err := frac.IntegerMultiplierOf(); err != nil || got != 1 {
+		t.Fatalf("IntegerMultiplierOf() = %d, %v, want 1", got, err)
+	}
+
+	_, err = frac.IntegerMultiplierOf(mustNew(t, 1, 4294967311), mustNew(t, 1, 4294967357))
+	if !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("overflowing lcm error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// --- IsDyadic / BinaryExponent ---------------------------------------------
+
+func TestIsDyadicAndBinaryExponent(t *testing.T) {
+	cases := []struct {
+		f      frac.Fraction
+		dyadic bool
+		k      int
+	}{
+		{mustNew(t, 3, 8), true, 3},
+		{mustNew(t, -1, 2), true, 1},
+		{frac.NewI(5), true, 0},
+		{frac.Zero(), true, 0},
+		{mustNew(t, 1, 3), false, 0},
+		{mustNew(t, 3, 10), false, 0},
+		{frac.MustNew(1, uint64(1)<<63), true, 63},
+	}
+	for _, c := range cases {
+		if got := c.f.IsDyadic(); got != c.dyadic {
+			t.Fatalf("(%v).IsDyadic() = %v, want %v", c.f, got, c.dyadic)
+		}
+		k, ok := c.f.BinaryExponent()
+		if ok != c.dyadic || k != c.k {
+			t.Fatalf("(%v).BinaryExponent() = %d, %v, want %d, %v", c.f, k, ok, c.k, c.dyadic)
+		}
+	}
+}
+
+// --- AsUnitFraction --------------------------------------------------------
+
+func TestAsUnitFraction(t *testing.T) {
+	cases := []struct {
+		f  frac.Fraction
+		d  uint64
+		ok bool
+	}{
+		{mustNew(t, 1, 7), 7, true},
+		{mustNew(t, 3, 21), 7, true},
+		{frac.One(), 1, true},
+		{mustNew(t, 2, 7), 0, false},
+		{mustNew(t, -1, 7), 0, false},
+		{frac.Zero(), 0, false},
+	}
+	for _, c := range cases {
+		d, ok := c.f.AsUnitFraction()
+		if d != c.d || ok != c.ok {
+			t.Fatalf("(%v).AsUnitFraction() = %d, %v, want %d, %v", c.f, d, ok, c.d, c.ok)
+		}
+	}
+}
+
+// --- OnGrid / GridIndex ----------------------------------------------------
+
+func TestOnGrid(t *testing.T) {
+	cases := []struct {
+		f       frac.Fraction
+		gridDen uint64
+		on      bool
+		index   int64
+	}{
+		{mustNew(t, 1, 4), 8, true, 2},
+		{mustNew(t, 1, 3), 8, false, 0},
+		{mustNew(t, -3, 4), 4, true, -3},
+		{mustNew(t, 5, 1), 3, true, 15},
+		{frac.Zero(), 7, true, 0},
+		{mustNew(t, 1, 2), 0, false, 0},
+	}
+	for _, c := range cases {
+		if got := c.f.OnGrid(c.gridDen); got != c.on {
+			t.Fatalf("(%v).OnGrid(%d) = %v, want %v", c.f, c.gridDen, got, c.on)
+		}
+		k, ok := c.f.GridIndex(c.gridDen)
+		if k != c.index || ok != c.on {
+			t.Fatalf("(%v).GridIndex(%d) = %d, %v, want %d, %v", c.f, c.gridDen, k, ok, c.index, c.on)
+		}
+	}
+}
+
+func TestGridIndex_Overflow(t *testing.T) {
+	f := frac.NewI(uint64(1) << 62)
+	if k, ok := f.GridIndex(4); ok {
+		t.Fatalf("(%v).GridIndex(4) = %d, true, want overflow", f, k)
+	}
+	if k, ok := f.Negate().GridIndex(2); !ok || k != -1<<63 {
+		t.Fatalf("(%v).GridIndex(2) = %d, %v, want MinInt64, true", f.Negate(), k, ok)
+	}
+}
+
+// --- InvertibleMod / Mod ---------------------------------------------------
+
+func TestMod(t *testing.T) {
+	cases := []struct {
+		f    frac.Fraction
+		m    uint64
+		want uint64
+		ok   bool
+	}{
+		{mustNew(t, 1, 3), 7, 5, true},
+		{mustNew(t, -1, 3), 7, 2, true},
+		{mustNew(t, 2, 3), 7, 3, true},
+		{mustNew(t, 10, 1), 7, 3, true},
+		{mustNew(t, -14, 1), 7, 0, true},
+		{frac.Zero(), 7, 0, true},
+		{mustNew(t, 5, 3), 1, 0, true},
+		{mustNew(t, 1, 3), 9, 0, false},
+		{mustNew(t, 1, 2), 10, 0, false},
+		{mustNew(t, 1, 3), 0, 0, false},
+	}
+	for _, c := range cases {
+		if got := c.f.InvertibleMod(c.m); got != c.ok {
+			t.Fatalf("(%v).InvertibleMod(%d) = %v, want %v", c.f, c.m, got, c.ok)
+		}
+		got, ok := c.f.Mod(c.m)
+		if got != c.want || ok != c.ok {
+			t.Fatalf("(%v).Mod(%d) = %d, %v, want %d, %v", c.f, c.m, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestMod_LargeModulus(t *testing.T) {
+	// 2^64 - 59 is the largest prime below 2^64
+	const p = uint64(18446744073709551557)
+	for _, d := range []uint64{2, 3, 12345, p - 1, 1 << 63} {
+		f := frac.MustNew(uint64(1), d)
+		inv, ok := f.Mod(p)
+		if !ok {
+			t.Fatalf("(%v).Mod(%d) undefined", f, p)
+		}
+		// d * (1/d) has to be 1 mod p
+		hi, lo := bits.Mul64(d%p, inv)
+		if _, rem := bits.Div64(hi, lo, p); rem != 1 {
+			t.Fatalf("%d * (%v).Mod(%d) = %d mod p, want 1", d, f, p, rem)
+		}
+	}
+}
+
+// --- IsInversePowerOfTwo ---------------------------------------------------
+
+func TestIsInversePowerOfTwo(t *testing.T) {
+	cases := []struct {
+		f  frac.Fraction
+		k  uint
+		ok bool
+	}{
+		{mustNew(t, 1, 8), 3, true},
+		{mustNew(t, 1, 2), 1, true},
+		{frac.One(), 0, true},
+		{frac.MustNew(uint64(1), uint64(1)<<63), 63, true},
+		{mustNew(t, 3, 8), 0, false},
+		{mustNew(t, 1, 6), 0, false},
+		{frac.NewI(2), 0, false},
+		{mustNew(t, -1, 8), 0, false},
+		{frac.Zero(), 0, false},
+	}
+	for _, c := range cases {
+		k, ok := c.f.IsInversePowerOfTwo()
+		if k != c.k || ok != c.ok {
+			t.Fatalf("(%v).IsInversePowerOfTwo() = %d, %v, want %d, %v", c.f, k, ok, c.k, c.ok)
+		}
+	}
+}
+
+// --- CommonGrid ------------------------------------------------------------
+
+func TestCommonGrid(t *testing.T) {
+	cases := []struct {
+		a, b frac.Fraction
+		want string
+	}{
+		{mustNew(t, 1, 3), mustNew(t, 1, 4), "1/12"},
+		{mustNew(t, 2, 3), mustNew(t, 5, 6), "1/6"},
+		{mustNew(t, -3, 4), mustNew(t, 1, 10), "1/20"},
+		{frac.NewI(2), frac.NewI(5), "1"},
+		{frac.Zero(), mustNew(t, 1, 7), "1/7"},
+	}
+	for _, c := range cases {
+		got, err := frac.CommonGrid(c.a, c.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("CommonGrid(%v, %v) = %v, want %s", c.a, c.b, got, c.want)
+		}
+		for _, f := range []frac.Fraction{c.a, c.b} {
+			if ok, err := f.IsMultipleOf(got); err != nil || !ok {
+				t.Fatalf("%v isn't a multiple of CommonGrid(%v, %v) = %v", f, c.a, c.b, got)
+			}
+		}
+	}
+
+	a := frac.MustNew(uint64(1), uint64(1)<<32+15)
+	b := frac.MustNew(uint64(1), uint64(1)<<32+17)
+	if _, err := frac.CommonGrid(a, b); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("CommonGrid(%v, %v) error = %v, want ErrOutOfRange", a, b, err)
+	}
+}
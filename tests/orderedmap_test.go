@@ -0,0 +1,85 @@
+package fraction_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- OrderedFractionMap ----------------------------------------------------
+
+// dump writes the map entries in iteration order as "key=value" pairs
+func dump[V any](m *frac.OrderedFractionMap[V]) string {
+	var parts []string
+	m.Range(func(k frac.Fraction, v V) bool {
+		parts = append(parts, fmt.Sprintf("%v=%v", k, v))
+		return true
+	})
+	return strings.Join(parts, " ")
+}
+
+func TestOrderedFractionMap(t *testing.T) {
+	var m frac.OrderedFractionMap[string]
+	if _, ok := m.Get(frac.One()); ok || m.Len() != 0 {
+		t.Fatalf("zero value map should be empty")
+	}
+
+	m.Set(mustNew(t, 3, 4), "c")
+	m.Set(mustNew(t, -1, 2), "a")
+	m.Set(frac.NewI(2), "d")
+	m.Set(mustNew(t, 1, 3), "b")
+	if got, want := dump(&m), "-1/2=a 1/3=b 3/4=c 2=d"; got != want {
+		t.Fatalf("entries = %q, want %q", got, want)
+	}
+
+	// 2/4 and 1/2 are the same key
+	m.Set(mustNew(t, 2, 4), "x")
+	m.Set(mustNew(t, 1, 2), "y")
+	if v, ok := m.Get(mustNew(t, 3, 6)); !ok || v != "y" {
+		t.Fatalf("Get(3/6) = %q, %v, want y, true", v, ok)
+	}
+	if m.Len() != 5 {
+		t.Fatalf("Len() = %d, want 5", m.Len())
+	}
+
+	m.Delete(mustNew(t, 6, 8))
+	m.Delete(frac.NewI(100)) // not there
+	if got, want := dump(&m), "-1/2=a 1/3=b 1/2=y 2=d"; got != want {
+		t.Fatalf("entries after delete = %q, want %q", got, want)
+	}
+	if _, ok := m.Get(mustNew(t, 3, 4)); ok {
+		t.Fatalf("Get(3/4) found a deleted key")
+	}
+}
+
+func TestOrderedFractionMap_RangeStops(t *testing.T) {
+	var m frac.OrderedFractionMap[int]
+	for i := range 10 {
+		m.Set(mustNew(t, int64(i), 3), i)
+	}
+
+	var seen []int
+	m.Range(func(_ frac.Fraction, v int) bool {
+		seen = append(seen, v)
+		return len(seen) < 3
+	})
+	if fmt.Sprint(seen) != "[0 1 2]" {
+		t.Fatalf("Range visited %v, want [0 1 2]", seen)
+	}
+}
+
+func TestOrderedFractionMap_HugeKeys(t *testing.T) {
+	// Keys whose cross products overflow 64 bits still order exactly
+	const m64 = int64(9223372036854775807)
+	a := mustNew(t, m64-1, m64)
+	b := mustNew(t, m64-2, m64-1)
+
+	var m frac.OrderedFractionMap[string]
+	m.Set(a, "a")
+	m.Set(b, "b")
+	if got, want := dump(&m), fmt.Sprintf("%v=b %v=a", b, a); got != want {
+		t.Fatalf("entries = %q, want %q", got, want)
+	}
+}
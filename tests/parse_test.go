@@ -0,0 +1,212 @@
+package fraction_test
+
+import (
+	"errors"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- ParseDecimalRaw -------------------------------------------------------
+
+func TestParseDecimalRaw(t *testing.T) {
+	cases := []struct {
+		in       string
+		num, den uint64
+		neg      bool
+	}{
+		{"0.20", 20, 100, false},
+		{"-1.5", 15, 10, true},
+		{" 42 ", 42, 1, false},
+		{"3.000", 3000, 1000, false},
+		{"0.05", 5, 100, false},
+		{"-0.0", 0, 10, false},
+	}
+	for _, c := range cases {
+		num, den, neg, err := frac.ParseDecimalRaw(c.in)
+		if err != nil {
+			t.Fatalf("ParseDecimalRaw(%q): %v", c.in, err)
+		}
+		if num != c.num || den != c.den || neg != c.neg {
+			t.Fatalf("ParseDecimalRaw(%q) = %d, %d, %v, want %d, %d, %v", c.in, num, den, neg, c.num, c.den, c.neg)
+		}
+	}
+}
+
+func TestParseDecimalRaw_Invalid(t *testing.T) {
+	bad := []string{"", "-", ".5", "1.", "1.2.3", "abc", "1.a", "+1"}
+	for _, in := range bad {
+		if _, _, _, err := frac.ParseDecimalRaw(in); err == nil {
+			t.Fatalf("ParseDecimalRaw(%q) should fail", in)
+		}
+	}
+}
+
+func TestParseDecimalRaw_OutOfRange(t *testing.T) {
+	for _, in := range []string{"18446744073709551616", "0.00000000000000000001", "1844674407370955161.6"} {
+		if _, _, _, err := frac.ParseDecimalRaw(in); !errors.Is(err, frac.ErrOutOfRange) {
+			t.Fatalf("ParseDecimalRaw(%q) error = %v, want ErrOutOfRange", in, err)
+		}
+	}
+}
+
+// --- FromDecimalStrings ----------------------------------------------------
+
+func TestFromDecimalStrings(t *testing.T) {
+	cases := []struct {
+		num, den, want string
+	}{
+		{"1000000000000000000000", "2000000000000000000000", "1/2"},
+		{"-36893488147419103232", "18446744073709551616", "-2"},
+		{"6", "-8", "-3/4"},
+		{" 0 ", "123456789012345678901234567890", "0"},
+		{"18446744073709551615", "1", "18446744073709551615"},
+	}
+	for _, c := range cases {
+		got, err := frac.FromDecimalStrings(c.num, c.den)
+		if err != nil {
+			t.Fatalf("FromDecimalStrings(%q, %q): %v", c.num, c.den, err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("FromDecimalStrings(%q, %q) = %v, want %s", c.num, c.den, got, c.want)
+		}
+	}
+}
+
+func TestFromDecimalStrings_Errors(t *testing.T) {
+	cases := []struct {
+		num, den string
+		want     error
+	}{
+		{"1", "0", frac.ErrZeroDenominator},
+		{"1", "-000", frac.ErrZeroDenominator},
+		{"1.5", "2", frac.ErrInvalid},
+		{"", "2", frac.ErrInvalid},
+		{"1", "abc", frac.ErrInvalid},
+		{"18446744073709551616", "1", frac.ErrOutOfRange},
+		{"1", "36893488147419103233", frac.ErrOutOfRange},
+	}
+	for _, c := range cases {
+		if _, err := frac.FromDecimalStrings(c.num, c.den); !errors.Is(err, c.want) {
+			t.Fatalf("FromDecimalStrings(%q, %q) error = %v, want %v", c.num, c.den, err, c.want)
+		}
+	}
+}
+
+// --- ParseEuropean ---------------------------------------------------------
+
+func TestParseEuropean(t *testing.T) {
+	valid := []struct {
+		in, want string
+	}{
+		{"1 234,56", "30864/25"},
+		{"1234,56", "30864/25"},
+		{"1.234,56", "30864/25"},
+		{"1\u00a0234,56", "30864/25"},
+		{"12 345 678", "12345678"},
+		{"-0,5", "-1/2"},
+		{" - 1 000,25 ", "-4001/4"},
+		{"0,125", "1/8"},
+		{"42", "42"},
+		{"999,000", "999"},
+	}
+	for _, c := range valid {
+		got, err := frac.ParseEuropean(c.in)
+		if err != nil {
+			t.Fatalf("ParseEuropean(%q) error = %v", c.in, err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("ParseEuropean(%q) = %v, want %s", c.in, got, c.want)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"-",
+		"1,234.56",
+		"1.234.5",
+		"12 34,5",
+		"1234 567",
+		"1 234.567,5",
+		"1,2,3",
+		"1,",
+		",5",
+		"1,5.0",
+		"1 ,5",
+		"1  234",
+		"abc",
+	}
+	for _, in := range invalid {
+		if got, err := frac.ParseEuropean(in); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("ParseEuropean(%q) = %v, %v, want ErrInvalid", in, got, err)
+		}
+	}
+
+	if _, err := frac.ParseEuropean("99 999 999 999 999 999 999"); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("huge number error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// --- ParsePrefix -----------------------------------------------------------
+
+func TestParsePrefix(t *testing.T) {
+	cases := []struct {
+		in, want, rest string
+	}{
+		{"1/2x", "1/2", "x"},
+		{"1/2 cup flour", "1/2", " cup flour"},
+		{"  3/4", "3/4", ""},
+		{"-6/8 of it", "-3/4", " of it"},
+		{"1 1/2 cups", "3/2", " cups"},
+		{"-2 3/4in", "-11/4", "in"},
+		{"2 5/4", "2", " 5/4"}, // improper, not a mixed number
+		{"2 cups", "2", " cups"},
+		{"1.25kg", "5/4", "kg"},
+		{"0.5.3", "1/2", ".3"},
+		{"3/ 4", "3", "/ 4"},
+		{"7.", "7", "."},
+		{"12", "12", ""},
+		{"-0 eggs", "0", " eggs"},
+		{"- 3/4", "-3/4", ""},
+		{"\u22123/4 cup", "-3/4", " cup"},
+		{"\u2013 1 1/2 ", "-3/2", " "},
+	}
+	for _, c := range cases {
+		got, rest, err := frac.ParsePrefix(c.in)
+		if err != nil {
+			t.Fatalf("ParsePrefix(%q): %v", c.in, err)
+		}
+		if got.String() != c.want || rest != c.rest {
+			t.Fatalf("ParsePrefix(%q) = %v, %q, want %s, %q", c.in, got, rest, c.want, c.rest)
+		}
+	}
+}
+
+func TestParsePrefix_SameSignsAsParseFracString(t *testing.T) {
+	for _, in := range []string{"-3/4", "- 3/4", " -\t3/4", "\u22123/4", "\u2212 3/4", "\u20133/4"} {
+		want, err := frac.ParseFracString(in)
+		if err != nil {
+			t.Fatalf("ParseFracString(%q): %v", in, err)
+		}
+		got, rest, err := frac.ParsePrefix(in)
+		if err != nil || got != want || rest != "" {
+			t.Fatalf("ParsePrefix(%q) = %v, %q, %v, want %v like ParseFracString", in, got, rest, err, want)
+		}
+	}
+}
+
+func TestParsePrefix_Errors(t *testing.T) {
+	cases := map[string]error{
+		"":                      frac.ErrInvalid,
+		"cup":                   frac.ErrInvalid,
+		"-x":                    frac.ErrInvalid,
+		"/2":                    frac.ErrInvalid,
+		"1/0 cup":               frac.ErrZeroDenominator,
+		"18446744073709551616x": frac.ErrOutOfRange,
+	}
+	for in, want := range cases {
+		if _, rest, err := frac.ParsePrefix(in); !errors.Is(err, want) || rest != in {
+			t.Fatalf("ParsePrefix(%q) = rest %q, error %v, want %v and the input untouched", in, rest, err, want)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package fraction_test
+
+import (
+	"errors"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- PercentDifference / PercentChangeString -------------------------------
+
+func TestPercentDifference(t *testing.T) {
+	cases := []struct {
+		a, b frac.Fraction
+		want string
+	}{
+		{frac.NewI(4), frac.NewI(5), "1/4"},
+		{frac.NewI(5), frac.NewI(4), "-1/5"},
+		{frac.NewI(3), frac.NewI(3), "0"},
+		{frac.NewI(-2), frac.NewI(-1), "-1/2"},
+		{mustNew(t, 1, 2), mustNew(t, 3, 4), "1/2"},
+	}
+	for _, c := range cases {
+		got, err := frac.PercentDifference(c.a, c.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("PercentDifference(%v, %v) = %v, want %s", c.a, c.b, got, c.want)
+		}
+	}
+
+	if _, err := frac.PercentDifference(frac.Zero(), frac.One()); !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("PercentDifference from zero error = %v, want ErrDivideByZero", err)
+	}
+}
+
+func TestPercentChangeString(t *testing.T) {
+	cases := []struct {
+		a, b frac.Fraction
+		want string
+	}{
+		{frac.NewI(4), frac.NewI(5), "+25%"},
+		{frac.NewI(3), frac.NewI(2), "-33.33%"},
+		{frac.NewI(3), frac.NewI(5), "+66.67%"},
+		{frac.NewI(8), frac.NewI(7), "-12.5%"},
+		{frac.NewI(7), frac.NewI(7), "0%"},
+		{frac.NewI(1), frac.NewI(3), "+200%"},
+		{frac.NewI(100000), frac.NewI(100001), "0%"},
+	}
+	for _, c := range cases {
+		got, err := frac.PercentChangeString(c.a, c.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Fatalf("PercentChangeString(%v, %v) = %q, want %q", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+// --- PartsPer / PartsPerString ---------------------------------------------
+
+func TestPartsPer(t *testing.T) {
+	cases := []struct {
+		f     frac.Fraction
+		base  uint64
+		want  int64
+		exact bool
+	}{
+		{mustNew(t, 3, 1000000), 1000000, 3, true},
+		{mustNew(t, 7, 1000000000), 1000000000, 7, true},
+		{mustNew(t, 7, 1000000000), 1000000, 0, false},
+		{mustNew(t, 1, 200), 1000000, 5000, true},
+		{mustNew(t, 1, 3), 1000000, 333333, false},
+		{mustNew(t, 2, 3), 1000000, 666667, false},
+		{mustNew(t, -1, 8), 1000, -125, true},
+		{frac.NewI(uint64(1) << 62), 4, 0, false},
+		{mustNew(t, 1, 2), 0, 0, false},
+	}
+	for _, c := range cases {
+		got, exact := c.f.PartsPer(c.base)
+		if got != c.want || exact != c.exact {
+			t.Fatalf("(%v).PartsPer(%d) = %d, %v, want %d, %v", c.f, c.base, got, exact, c.want, c.exact)
+		}
+	}
+}
+
+func TestPartsPerString(t *testing.T) {
+	cases := []struct {
+		f    frac.Fraction
+		base uint64
+		want string
+	}{
+		{mustNew(t, 3, 1000000), 1000000, "3 ppm"},
+		{mustNew(t, 5, 2000000000), 1000000000, "2.5 ppb"},
+		{mustNew(t, 1, 3), 1000000, "333333.33 ppm"},
+		{mustNew(t, 1, 1000000000000), 1000000000000, "1 ppt"},
+		{mustNew(t, 1, 4), 100, "25%"},
+		{mustNew(t, 1, 8), 1000, "125 per 1000"},
+		{frac.Zero(), 1000000, "0 ppm"},
+	}
+	for _, c := range cases {
+		got, err := c.f.PartsPerString(c.base)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Fatalf("(%v).PartsPerString(%d) = %q, want %q", c.f, c.base, got, c.want)
+		}
+	}
+
+	if _, err := mustNew(t, 1, 2).PartsPerString(0); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("base 0 error = %v, want ErrInvalid", err)
+	}
+}
+
+// --- PercentOf -------------------------------------------------------------
+
+func TestPercentOf(t *testing.T) {
+	total := mustNew(t, 2, 3)
+	cases := []struct {
+		f     frac.Fraction
+		round frac.RoundingMode
+		want  int
+	}{
+		// Exactly full
+		{mustNew(t, 2, 3), frac.RoundDown, 100},
+		{mustNew(t, 2, 3), frac.RoundNearest, 100},
+		// Just under full
+		{mustNew(t, 1999, 3000), frac.RoundDown, 99},
+		{mustNew(t, 1999, 3000), frac.RoundNearest, 100},
+		{mustNew(t, 1999, 3000), frac.RoundUp, 100},
+		// Just above empty
+		{mustNew(t, 1, 3000), frac.RoundDown, 0},
+		{mustNew(t, 1, 3000), frac.RoundUp, 1},
+		{mustNew(t, 1, 3000), frac.RoundNearest, 0},
+		// Halves go away from zero
+		{mustNew(t, 1, 300), frac.RoundNearest, 1},
+		{mustNew(t, 1, 3), frac.RoundNearest, 50},
+		// Clamped
+		{frac.One(), frac.RoundDown, 100},
+		{mustNew(t, -1, 3), frac.RoundUp, 0},
+		{frac.Zero(), frac.RoundUp, 0},
+	}
+	for _, c := range cases {
+		got, err := c.f.PercentOf(total, c.round)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Fatalf("(%v).PercentOf(%v, %d) = %d, want %d", c.f, total, c.round, got, c.want)
+		}
+	}
+}
+
+func TestPercentOf_Errors(t *testing.T) {
+	if _, err := frac.One().PercentOf(frac.Zero(), frac.RoundDown); !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("zero total error = %v, want ErrDivideByZero", err)
+	}
+	if _, err := frac.One().PercentOf(frac.One(), frac.RoundingMode(42)); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("unknown rounding mode error = %v, want ErrInvalid", err)
+	}
+}
+
+// --- RatioOf ---------------------------------------------------------------
+
+func TestRatioOf(t *testing.T) {
+	cases := []struct {
+		a, b         frac.Fraction
+		numer, denom uint64
+		negative     bool
+	}{
+		{mustNew(t, 1, 2), mustNew(t, 1, 3), 3, 2, false},
+		{frac.NewI(4), frac.NewI(6), 2, 3, false},
+		{mustNew(t, 3, 4), mustNew(t, 9, 8), 2, 3, false},
+		{mustNew(t, 5, 6), mustNew(t, 5, 6), 1, 1, false},
+		{mustNew(t, -1, 2), mustNew(t, 1, 4), 2, 1, true},
+		{mustNew(t, -1, 2), mustNew(t, -1, 4), 2, 1, false},
+		{frac.Zero(), mustNew(t, 1, 4), 0, 1, false},
+	}
+	for _, c := range cases {
+		numer, denom, negative, err := frac.RatioOf(c.a, c.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if numer != c.numer || denom != c.denom || negative != c.negative {
+			t.Fatalf("RatioOf(%v, %v) = %d:%d (negative %v), want %d:%d (negative %v)",
+				c.a, c.b, numer, denom, negative, c.numer, c.denom, c.negative)
+		}
+	}
+
+	if _, _, _, err := frac.RatioOf(frac.One(), frac.Zero()); !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("zero b error = %v, want ErrDivideByZero", err)
+	}
+}
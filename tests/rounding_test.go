@@ -0,0 +1,483 @@
+package fraction_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- FractionsWithDenominator ----------------------------------------------
+
+func TestFractionsWithDenominator(t *testing.T) {
+	cases := []struct {
+		a, b frac.Fraction
+		d    uint64
+		want string
+	}{
+		{frac.Zero(), frac.One(), 4, "[1/4 1/2 3/4]"},
+		{mustNew(t, 1, 4), mustNew(t, 3, 4), 4, "[1/2]"},
+		{mustNew(t, 1, 5), mustNew(t, 4, 5), 4, "[1/4 1/2 3/4]"},
+		{mustNew(t, -3, 4), mustNew(t, 1, 3), 2, "[-1/2 0]"},
+		{mustNew(t, -7, 3), mustNew(t, -1, 3), 1, "[-2 -1]"},
+		{mustNew(t, 1, 3), mustNew(t, 2, 5), 4, "[]"},
+		{frac.NewI(1), frac.NewI(2), 3, "[4/3 5/3]"},
+	}
+	for _, c := range cases {
+		got, err := frac.FractionsWithDenominator(c.a, c.b, c.d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := fmt.Sprint(got); s != c.want {
+			t.Fatalf("FractionsWithDenominator(%v, %v, %d) = %s, want %s", c.a, c.b, c.d, s, c.want)
+		}
+	}
+}
+
+func TestFractionsWithDenominator_Errors(t *testing.T) {
+	a, b := frac.Zero(), frac.One()
+	if _, err := frac.FractionsWithDenominator(a, b, 0); !errors.Is(err, frac.ErrZeroDenominator) {
+		t.Fatalf("d=0 error = %v, want ErrZeroDenominator", err)
+	}
+	if _, err := frac.FractionsWithDenominator(b, a, 4); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("a>b error = %v, want ErrInvalid", err)
+	}
+	if _, err := frac.FractionsWithDenominator(a, a, 4); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("a==b error = %v, want ErrInvalid", err)
+	}
+	if _, err := frac.FractionsWithDenominator(a, frac.NewI(uint64(1)<<62), 4); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("huge range error = %v, want ErrOutOfRange", err)
+	}
+	if _, err := frac.FractionsWithDenominator(a, frac.NewI(1000), 1<<20); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("too many fractions error = %v, want ErrOutOfRange", err)
+	}
+	// Right at the limit is still fine
+	if got, err := frac.FractionsWithDenominator(a, frac.One(), 1<<20+1); err != nil || len(got) != 1<<20 {
+		t.Fatalf("FractionsWithDenominator(0, 1, 2^20+1) = %d values, %v, want 2^20", len(got), err)
+	}
+}
+
+// --- RationalLattice -------------------------------------------------------
+
+func TestRationalLattice(t *testing.T) {
+	cases := []struct {
+		lo, hi frac.Fraction
+		maxDen uint64
+		want   string
+	}{
+		{frac.NewI(1), frac.NewI(2), 3, "[1 4/3 3/2 5/3 2]"},
+		{frac.Zero(), frac.One(), 5, "[0 1/5 1/4 1/3 2/5 1/2 3/5 2/3 3/4 4/5 1]"},
+		{mustNew(t, -1, 2), mustNew(t, 1, 2), 2, "[-1/2 0 1/2]"},
+		{mustNew(t, -5, 4), mustNew(t, -2, 3), 3, "[-1 -2/3]"},
+		{mustNew(t, 3, 10), mustNew(t, 3, 10), 10, "[3/10]"},
+		{mustNew(t, 3, 10), mustNew(t, 3, 10), 9, "[]"},
+		{mustNew(t, 1, 4), mustNew(t, 1, 3), 3, "[1/3]"},
+		{mustNew(t, 1, 3), mustNew(t, 2, 5), 4, "[1/3]"},
+	}
+	for _, c := range cases {
+		got, err := frac.RationalLattice(c.lo, c.hi, c.maxDen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := fmt.Sprint(got); s != c.want {
+			t.Fatalf("RationalLattice(%v, %v, %d) = %s, want %s", c.lo, c.hi, c.maxDen, s, c.want)
+		}
+	}
+
+	// Over [0, 1] it's the Farey sequence, so it agrees with FareyRank
+	lattice, err := frac.RationalLattice(frac.Zero(), frac.One(), 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, f := range lattice {
+		if rank, err := frac.FareyRank(f, 30); err != nil || rank != i {
+			t.Fatalf("FareyRank(%v, 30) = %d, %v, want %d", f, rank, err, i)
+		}
+	}
+}
+
+func TestRationalLattice_Errors(t *testing.T) {
+	a, b := frac.Zero(), frac.One()
+	if _, err := frac.RationalLattice(a, b, 0); !errors.Is(err, frac.ErrZeroDenominator) {
+		t.Fatalf("maxDen=0 error = %v, want ErrZeroDenominator", err)
+	}
+	if _, err := frac.RationalLattice(b, a, 4); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("lo>hi error = %v, want ErrInvalid", err)
+	}
+	if _, err := frac.RationalLattice(a, frac.NewI(uint64(1)<<62), 4); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("huge range error = %v, want ErrOutOfRange", err)
+	}
+	if _, err := frac.RationalLattice(a, frac.NewI(1<<20), 2); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("too many fractions error = %v, want ErrOutOfRange", err)
+	}
+	if _, err := frac.RationalLattice(a, b, math.MaxUint64); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("maxDen=MaxUint64 error = %v, want ErrOutOfRange", err)
+	}
+	half := mustNew(t, 1, 2)
+	if _, err := frac.RationalLattice(half, half, 1e12); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("maxDen=1e12 error = %v, want ErrOutOfRange", err)
+	}
+	if got, err := frac.RationalLattice(half, half, 1<<20); err != nil || fmt.Sprint(got) != "[1/2]" {
+		t.Fatalf("RationalLattice(1/2, 1/2, 2^20) = %v, %v, want [1/2]", got, err)
+	}
+}
+
+// --- QuantizeDecimal -------------------------------------------------------
+
+func TestQuantizeDecimal(t *testing.T) {
+	cases := []struct {
+		f      frac.Fraction
+		places int
+		want   string
+	}{
+		{mustNew(t, 1, 3), 2, "33/100"},
+		{mustNew(t, 2, 3), 2, "67/100"},
+		{mustNew(t, -2, 3), 2, "-67/100"},
+		{mustNew(t, 1, 8), 2, "13/100"}, // 0.125 rounds away from zero
+		{mustNew(t, -1, 8), 2, "-13/100"},
+		{mustNew(t, 1, 3), 0, "0"},
+		{mustNew(t, 5, 2), 0, "3"},
+		{mustNew(t, 3, 4), 5, "3/4"},
+		{mustNew(t, 1, 3), 19, "3333333333333333333/10000000000000000000"},
+	}
+	for _, c := range cases {
+		got, err := c.f.QuantizeDecimal(c.places)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("(%v).QuantizeDecimal(%d) = %v, want %s", c.f, c.places, got, c.want)
+		}
+	}
+}
+
+func TestQuantizeDecimal_Errors(t *testing.T) {
+	f := mustNew(t, 1, 3)
+	if _, err := f.QuantizeDecimal(-1); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("negative places error = %v, want ErrInvalid", err)
+	}
+	if _, err := f.QuantizeDecimal(20); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("20 places error = %v, want ErrOutOfRange", err)
+	}
+	if _, err := frac.NewI(uint64(1) << 62).QuantizeDecimal(2); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("overflowing scale error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// --- DenominatorAllowed / OnDenominatorGrid / CoerceToAllowed --------------
+
+func TestDenominatorAllowed(t *testing.T) {
+	quarters := []uint64{4}
+	cases := []struct {
+		f            frac.Fraction
+		allowed      []uint64
+		member, grid bool
+	}{
+		{mustNew(t, 3, 4), quarters, true, true},
+		{mustNew(t, 1, 2), quarters, false, true},
+		{mustNew(t, 1, 2), []uint64{2, 4}, true, true},
+		{frac.NewI(-3), quarters, false, true},
+		{frac.NewI(-3), []uint64{1}, true, true},
+		{mustNew(t, 1, 3), quarters, false, false},
+		{mustNew(t, 1, 8), quarters, false, false},
+		{mustNew(t, 1, 8), []uint64{2, 4, 8}, true, true},
+		{mustNew(t, 7, 20), []uint64{100}, false, true},
+		{mustNew(t, 1, 3), []uint64{0, 6}, false, true},
+		{mustNew(t, 1, 3), nil, false, false},
+	}
+	for _, c := range cases {
+		if got := c.f.DenominatorAllowed(c.allowed); got != c.member {
+			t.Fatalf("(%v).DenominatorAllowed(%v) = %v, want %v", c.f, c.allowed, got, c.member)
+		}
+		if got := c.f.OnDenominatorGrid(c.allowed); got != c.grid {
+			t.Fatalf("(%v).OnDenominatorGrid(%v) = %v, want %v", c.f, c.allowed, got, c.grid)
+		}
+	}
+}
+
+func TestCoerceToAllowed(t *testing.T) {
+	quarters := []uint64{4}
+	cases := []struct {
+		f       frac.Fraction
+		allowed []uint64
+		round   frac.RoundingMode
+		want    string
+	}{
+		{mustNew(t, 1, 3), quarters, frac.RoundDown, "1/4"},
+		{mustNew(t, 1, 3), quarters, frac.RoundUp, "1/2"},
+		{mustNew(t, 1, 3), quarters, frac.RoundNearest, "1/4"},
+		{mustNew(t, -1, 3), quarters, frac.RoundDown, "-1/2"},
+		{mustNew(t, -1, 3), quarters, frac.RoundUp, "-1/4"},
+		{mustNew(t, -1, 3), quarters, frac.RoundNearest, "-1/4"},
+		{mustNew(t, 3, 8), quarters, frac.RoundNearest, "1/2"}, // halves away from zero
+		{mustNew(t, -3, 8), quarters, frac.RoundNearest, "-1/2"},
+		{mustNew(t, 3, 4), quarters, frac.RoundUp, "3/4"},
+		// Several grids, the best one wins
+		{mustNew(t, 1, 3), []uint64{4, 3}, frac.RoundDown, "1/3"},
+		{mustNew(t, 2, 7), []uint64{2, 3, 4}, frac.RoundDown, "1/4"},
+		{mustNew(t, 2, 7), []uint64{2, 3, 4}, frac.RoundUp, "1/3"},
+		{mustNew(t, 2, 7), []uint64{2, 3, 4}, frac.RoundNearest, "1/4"},
+		{mustNew(t, 5, 12), []uint64{3, 2}, frac.RoundNearest, "1/2"}, // 1/3 and 1/2 are as close, smaller denominator
+		{mustNew(t, 1, 3), []uint64{100}, frac.RoundNearest, "33/100"},
+	}
+	for _, c := range cases {
+		got, err := c.f.CoerceToAllowed(c.allowed, c.round)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("(%v).CoerceToAllowed(%v, %v) = %v, want %s", c.f, c.allowed, c.round, got, c.want)
+		}
+		if !got.OnDenominatorGrid(c.allowed) {
+			t.Fatalf("(%v).CoerceToAllowed(%v, %v) = %v, which isn't on an allowed grid", c.f, c.allowed, c.round, got)
+		}
+	}
+}
+
+func TestCoerceToAllowed_Errors(t *testing.T) {
+	f := mustNew(t, 1, 3)
+	if _, err := f.CoerceToAllowed(nil, frac.RoundNearest); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("empty allowed error = %v, want ErrInvalid", err)
+	}
+	if _, err := f.CoerceToAllowed([]uint64{4}, frac.RoundingMode(7)); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("unknown mode error = %v, want ErrInvalid", err)
+	}
+	if _, err := f.CoerceToAllowed([]uint64{4, 0}, frac.RoundNearest); !errors.Is(err, frac.ErrZeroDenominator) {
+		t.Fatalf("zero denominator error = %v, want ErrZeroDenominator", err)
+	}
+	huge := mustNew(t, 9223372036854775807, 3)
+	if _, err := huge.CoerceToAllowed([]uint64{4}, frac.RoundNearest); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("huge value error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// --- Bracket ---------------------------------------------------------------
+
+func TestBracket(t *testing.T) {
+	cases := []struct {
+		f           frac.Fraction
+		floor, ceil string
+	}{
+		{mustNew(t, -7, 3), "-3", "-2"},
+		{mustNew(t, 7, 3), "2", "3"},
+		{mustNew(t, 1, 2), "0", "1"},
+		{mustNew(t, -1, 2), "-1", "0"},
+		{frac.NewI(5), "5", "5"},
+		{frac.NewI(-5), "-5", "-5"},
+		{frac.Zero(), "0", "0"},
+	}
+	for _, c := range cases {
+		floor, ceil := c.f.Bracket()
+		if floor.String() != c.floor || ceil.String() != c.ceil {
+			t.Fatalf("(%v).Bracket() = %v, %v, want %s, %s", c.f, floor, ceil, c.floor, c.ceil)
+		}
+		if floor.IsNegative() && floor.Equal(frac.Zero()) || ceil.IsNegative() && ceil.Equal(frac.Zero()) {
+			t.Fatalf("(%v).Bracket() returned a negative zero", c.f)
+		}
+	}
+}
+
+// --- DistanceToNearestInteger ----------------------------------------------
+
+func TestDistanceToNearestInteger(t *testing.T) {
+	cases := []struct {
+		f    frac.Fraction
+		want string
+	}{
+		{mustNew(t, 7, 3), "1/3"},
+		{mustNew(t, 8, 3), "1/3"},
+		{mustNew(t, 5, 2), "1/2"},
+		{mustNew(t, -7, 3), "1/3"},
+		{mustNew(t, -5, 2), "1/2"},
+		{mustNew(t, -1, 10), "1/10"},
+		{mustNew(t, 9, 10), "1/10"},
+		{frac.NewI(4), "0"},
+		{frac.NewI(-4), "0"},
+		{frac.Zero(), "0"},
+		{mustNew(t, 9223372036854775807, 9223372036854775806), "1/9223372036854775806"},
+	}
+	for _, c := range cases {
+		if got := c.f.DistanceToNearestInteger(); got.String() != c.want {
+			t.Fatalf("(%v).DistanceToNearestInteger() = %v, want %s", c.f, got, c.want)
+		}
+	}
+}
+
+// --- QuantizeSymmetric -----------------------------------------------------
+
+func TestQuantizeSymmetric(t *testing.T) {
+	quarter := mustNew(t, 1, 4)
+	cases := []struct {
+		f, step frac.Fraction
+		want    string
+	}{
+		{mustNew(t, 3, 8), quarter, "1/2"},
+		{mustNew(t, -3, 8), quarter, "-1/2"},
+		{mustNew(t, 1, 8), quarter, "1/4"},
+		{mustNew(t, -1, 8), quarter, "-1/4"},
+		{mustNew(t, 1, 10), quarter, "0"},
+		{mustNew(t, -1, 10), quarter, "0"},
+		{mustNew(t, 7, 3), frac.One(), "2"},
+		{mustNew(t, -5, 2), frac.One(), "-3"},
+		{mustNew(t, 2, 3), mustNew(t, 2, 3), "2/3"},
+	}
+	for _, c := range cases {
+		got, err := c.f.QuantizeSymmetric(c.step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("(%v).QuantizeSymmetric(%v) = %v, want %s", c.f, c.step, got, c.want)
+		}
+		neg, err := c.f.Negate().QuantizeSymmetric(c.step)
+		if err != nil || !neg.Equal(got.Negate()) {
+			t.Fatalf("(%v).QuantizeSymmetric(%v) = %v, %v, want %v", c.f.Negate(), c.step, neg, err, got.Negate())
+		}
+	}
+}
+
+func TestQuantizeSymmetric_InvalidStep(t *testing.T) {
+	for _, step := range []frac.Fraction{frac.Zero(), mustNew(t, -1, 4)} {
+		if _, err := mustNew(t, 1, 2).QuantizeSymmetric(step); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("step %v error = %v, want ErrInvalid", step, err)
+		}
+	}
+}
+
+// --- UnitParts -------------------------------------------------------------
+
+func TestUnitParts(t *testing.T) {
+	cases := []struct {
+		f    frac.Fraction
+		want string
+	}{
+		{mustNew(t, 3, 4), "[0 1/4 1/2 3/4 1]"},
+		{mustNew(t, -2, 3), "[0 1/3 2/3 1]"},
+		{mustNew(t, 6, 8), "[0 1/4 1/2 3/4 1]"},
+		{frac.NewI(5), "[0 1]"},
+		{frac.Zero(), "[0 1]"},
+	}
+	for _, c := range cases {
+		parts, err := c.f.UnitParts()
+		if err != nil {
+			t.Fatalf("(%v).UnitParts() error: %v", c.f, err)
+		}
+		if got := fmt.Sprint(parts); got != c.want {
+			t.Fatalf("(%v).UnitParts() = %s, want %s", c.f, got, c.want)
+		}
+	}
+
+	parts, err := mustNew(t, 1, 1<<20-1).UnitParts()
+	if err != nil || len(parts) != 1<<20 {
+		t.Fatalf("(1/(2^20-1)).UnitParts() = %d parts, %v, want %d", len(parts), err, 1<<20)
+	}
+	for _, d := range []uint64{1 << 20, 1 << 40, math.MaxUint64} {
+		if _, err := frac.MustNew(uint64(1), d).UnitParts(); !errors.Is(err, frac.ErrOutOfRange) {
+			t.Fatalf("(1/%d).UnitParts() error = %v, want ErrOutOfRange", d, err)
+		}
+	}
+}
+
+// --- CountFits -------------------------------------------------------------
+
+func TestCountFits(t *testing.T) {
+	cases := []struct {
+		f, unit frac.Fraction
+		count   int64
+		rem     string
+	}{
+		{mustNew(t, 2, 3), mustNew(t, 1, 4), 2, "1/6"},
+		{mustNew(t, 3, 4), mustNew(t, 1, 4), 3, "0"},
+		{mustNew(t, 1, 8), mustNew(t, 1, 4), 0, "1/8"},
+		{frac.NewI(5), mustNew(t, 3, 2), 3, "1/2"},
+		{mustNew(t, -2, 3), mustNew(t, 1, 4), -3, "1/12"},
+		{mustNew(t, 2, 3), mustNew(t, -1, 4), -3, "-1/12"},
+		{mustNew(t, -2, 3), mustNew(t, -1, 4), 2, "-1/6"},
+		{frac.Zero(), mustNew(t, 1, 4), 0, "0"},
+	}
+	for _, c := range cases {
+		count, rem, err := c.f.CountFits(c.unit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != c.count || rem.String() != c.rem {
+			t.Fatalf("(%v).CountFits(%v) = %d, %v, want %d, %s", c.f, c.unit, count, rem, c.count, c.rem)
+		}
+	}
+}
+
+func TestCountFits_Errors(t *testing.T) {
+	if _, _, err := frac.One().CountFits(frac.Zero()); !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("zero unit error = %v, want ErrDivideByZero", err)
+	}
+	huge := frac.NewI(uint64(1) << 63)
+	if _, _, err := huge.CountFits(frac.One()); !errors.Is(err, frac.ErrOutOfRange) {
+		t.Fatalf("huge count error = %v, want ErrOutOfRange", err)
+	}
+}
+
+// --- WrapInto --------------------------------------------------------------
+
+func TestWrapInto(t *testing.T) {
+	three := frac.NewI(3)
+	cases := []struct {
+		f, m frac.Fraction
+		want string
+	}{
+		{mustNew(t, 7, 2), three, "1/2"},
+		{mustNew(t, -1, 2), three, "5/2"},
+		{frac.Zero(), three, "0"},
+		{three, three, "0"},
+		{frac.NewI(-3), three, "0"},
+		{frac.NewI(-6), three, "0"},
+		{mustNew(t, -7, 2), three, "5/2"},
+		{mustNew(t, -11, 2), three, "1/2"},
+		{mustNew(t, 5, 2), three, "5/2"},
+		{mustNew(t, 1, 2), mustNew(t, 1, 3), "1/6"},
+		{mustNew(t, -1, 2), mustNew(t, 1, 3), "1/6"},
+		{mustNew(t, -1, 100), frac.One(), "99/100"},
+		{frac.NewI(uint64(1) << 63), three, "2"},
+		{frac.NewI(-9223372036854775807), three, "2"},
+	}
+	for _, c := range cases {
+		got, err := c.f.WrapInto(c.m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != c.want {
+			t.Fatalf("(%v).WrapInto(%v) = %v, want %s", c.f, c.m, got, c.want)
+		}
+	}
+
+	// Stepping across zero keeps the result in [0, m) and moving with f
+	m := mustNew(t, 3, 2)
+	for n := int64(-20); n <= 20; n++ {
+		f := mustNew(t, n, 4)
+		got, err := f.WrapInto(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Less(frac.Zero()) || !got.Less(m) {
+			t.Fatalf("(%v).WrapInto(%v) = %v, outside of [0, %v)", f, m, got, m)
+		}
+		diff, err := frac.Subtract(f, got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := diff.IsMultipleOf(m); err != nil || !ok {
+			t.Fatalf("(%v).WrapInto(%v) = %v, which isn't a whole number of turns away", f, m, got)
+		}
+	}
+}
+
+func TestWrapInto_Errors(t *testing.T) {
+	f := mustNew(t, 1, 2)
+	if _, err := f.WrapInto(frac.Zero()); !errors.Is(err, frac.ErrDivideByZero) {
+		t.Fatalf("zero modulus error = %v, want ErrDivideByZero", err)
+	}
+	if _, err := f.WrapInto(frac.NewI(-3)); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("negative modulus error = %v, want ErrInvalid", err)
+	}
+}
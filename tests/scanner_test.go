@@ -0,0 +1,80 @@
+package fraction_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	frac "github.com/sea2horses/go-betterfractions"
+)
+
+// --- DecimalScanner --------------------------------------------------------
+
+var _ io.ByteWriter = (*frac.DecimalScanner)(nil)
+
+func scanDecimal(t *testing.T, s string) (frac.Fraction, error) {
+	t.Helper()
+	var sc frac.DecimalScanner
+	for i := range len(s) {
+		if err := sc.WriteByte(s[i]); err != nil {
+			return frac.Zero(), err
+		}
+	}
+	return sc.Fraction()
+}
+
+func TestDecimalScanner(t *testing.T) {
+	cases := map[string]string{
+		"-12.34":               "-617/50",
+		"12.34":                "617/50",
+		"+0.5":                 "1/2",
+		"007":                  "7",
+		"-0.0":                 "0",
+		"3.000":                "3",
+		"0.125":                "1/8",
+		"18446744073709551615": "18446744073709551615",
+	}
+	for in, want := range cases {
+		got, err := scanDecimal(t, in)
+		if err != nil {
+			t.Fatalf("scanning %q: %v", in, err)
+		}
+		if got.String() != want {
+			t.Fatalf("scanning %q = %v, want %s", in, got, want)
+		}
+		if parsed, err := frac.ParseDecimal(in); err == nil && !parsed.Equal(got) {
+			t.Fatalf("scanning %q = %v, but ParseDecimal gives %v", in, got, parsed)
+		}
+	}
+}
+
+func TestDecimalScanner_Invalid(t *testing.T) {
+	for _, in := range []string{"", "-", "+", ".5", "1.", "1.2.3", "1-2", "--1", "1e5", " 1"} {
+		if got, err := scanDecimal(t, in); !errors.Is(err, frac.ErrInvalid) {
+			t.Fatalf("scanning %q = %v, %v, want ErrInvalid", in, got, err)
+		}
+	}
+	for _, in := range []string{"18446744073709551616", "0.00000000000000000001"} {
+		if got, err := scanDecimal(t, in); !errors.Is(err, frac.ErrOutOfRange) {
+			t.Fatalf("scanning %q = %v, %v, want ErrOutOfRange", in, got, err)
+		}
+	}
+}
+
+func TestDecimalScanner_RejectedByteKeepsState(t *testing.T) {
+	var sc frac.DecimalScanner
+	for _, b := range []byte("1.5") {
+		if err := sc.WriteByte(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sc.WriteByte('.'); !errors.Is(err, frac.ErrInvalid) {
+		t.Fatalf("second '.' error = %v, want ErrInvalid", err)
+	}
+	if err := sc.WriteByte('2'); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := sc.Fraction(); err != nil || got.String() != "38/25" {
+		t.Fatalf("Fraction() = %v, %v, want 38/25", got, err)
+	}
+}